@@ -0,0 +1,219 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package availability computes service level statistics, such as uptime
+// percentages and error budget burn, from the time ranges of outages.
+//
+// DISCLAIMER: This library is not yet stable, so expect breaking changes.
+package availability
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/go-intervals/timespanset"
+)
+
+// Calculator computes statistics from a set of outages. Time within planned
+// maintenance is excluded from all statistics: it counts neither as uptime
+// nor as downtime.
+type Calculator struct {
+	outages, maintenance *timespanset.Set
+}
+
+// New returns a calculator for the given outages and planned maintenance.
+// Maintenance may be nil. The sets are copied, so later changes to them do not
+// affect the calculator.
+func New(outages, maintenance *timespanset.Set) *Calculator {
+	if maintenance == nil {
+		maintenance = timespanset.Empty()
+	}
+	return &Calculator{outages.Copy(), maintenance.Copy()}
+}
+
+// Report holds the statistics for a reporting period.
+type Report struct {
+	// Start and End are the bounds of the reporting period.
+	Start, End time.Time
+
+	// Eligible is the length of the reporting period excluding maintenance.
+	Eligible time.Duration
+
+	// Downtime is the eligible time within outages.
+	Downtime time.Duration
+
+	// Outages is the number of outages with eligible downtime in the period.
+	// An outage that is interrupted by maintenance is counted once.
+	Outages int
+
+	// Longest is the eligible downtime of the longest outage.
+	Longest time.Duration
+}
+
+// Uptime returns the percentage of eligible time that was not downtime. It
+// returns 100 if no time was eligible.
+func (r Report) Uptime() float64 {
+	if r.Eligible == 0 {
+		return 100
+	}
+	return 100 * float64(r.Eligible-r.Downtime) / float64(r.Eligible)
+}
+
+// MTTR returns the mean time to recovery: the mean eligible downtime of an
+// outage. It returns zero if there were no outages.
+func (r Report) MTTR() time.Duration {
+	if r.Outages == 0 {
+		return 0
+	}
+	return r.Downtime / time.Duration(r.Outages)
+}
+
+// MTBF returns the mean time between failures: the eligible time that was not
+// downtime divided by the number of outages. It returns zero if there were no
+// outages.
+func (r Report) MTBF() time.Duration {
+	if r.Outages == 0 {
+		return 0
+	}
+	return (r.Eligible - r.Downtime) / time.Duration(r.Outages)
+}
+
+// String returns a human readable version of the report.
+func (r Report) String() string {
+	return fmt.Sprintf("[%s, %s) uptime=%.4f%% downtime=%s outages=%d longest=%s",
+		r.Start.Format(time.RFC3339), r.End.Format(time.RFC3339), r.Uptime(), r.Downtime, r.Outages, r.Longest)
+}
+
+// between returns a new set containing the portion of s within [start, end).
+// It takes time proportional to the number of spans within [start, end), not
+// the size of s.
+func between(s *timespanset.Set, start, end time.Time) *timespanset.Set {
+	result := timespanset.Empty()
+	s.IntervalsBetween(start, end, func(s, e time.Time) bool {
+		result.Insert(s, e)
+		return true
+	})
+	return result
+}
+
+// eligible returns the time in [start, end) outside of maintenance.
+func (c *Calculator) eligible(start, end time.Time) *timespanset.Set {
+	result := timespanset.Empty()
+	result.Insert(start, end)
+	result.Sub(between(c.maintenance, start, end))
+	return result
+}
+
+// Report returns the statistics for [start, end).
+func (c *Calculator) Report(start, end time.Time) Report {
+	r := Report{Start: start, End: end}
+	if !start.Before(end) {
+		return r
+	}
+	eligible := c.eligible(start, end)
+	eligible.IntervalsBetween(start, end, func(s, e time.Time) bool {
+		r.Eligible += e.Sub(s)
+		return true
+	})
+	down := between(c.outages, start, end)
+	down.Intersect(eligible)
+	c.outages.IntervalsBetween(start, end, func(s, e time.Time) bool {
+		var d time.Duration
+		down.IntervalsBetween(s, e, func(s, e time.Time) bool {
+			d += e.Sub(s)
+			return true
+		})
+		if d == 0 {
+			return true
+		}
+		r.Downtime += d
+		r.Outages++
+		if d > r.Longest {
+			r.Longest = d
+		}
+		return true
+	})
+	return r
+}
+
+// Monthly returns a report for each calendar month in loc that overlaps
+// [start, end). The first and last reports are truncated to [start, end).
+func (c *Calculator) Monthly(start, end time.Time, loc *time.Location) []Report {
+	var result []Report
+	s := start.In(loc)
+	month := time.Date(s.Year(), s.Month(), 1, 0, 0, 0, 0, loc)
+	for month.Before(end) {
+		next := month.AddDate(0, 1, 0)
+		from, to := month, next
+		if from.Before(start) {
+			from = start
+		}
+		if to.After(end) {
+			to = end
+		}
+		result = append(result, c.Report(from, to))
+		month = next
+	}
+	return result
+}
+
+// Burn holds the error budget burn over a window of time.
+type Burn struct {
+	// Start and End are the bounds of the window.
+	Start, End time.Time
+
+	// Budget is the downtime allowed in the window by the target.
+	Budget time.Duration
+
+	// Downtime is the eligible downtime in the window.
+	Downtime time.Duration
+}
+
+// Fraction returns the fraction of the error budget that was used. Values
+// greater than 1 mean the budget was exceeded. If the budget is zero, any
+// downtime gives +Inf.
+func (b Burn) Fraction() float64 {
+	if b.Budget == 0 {
+		if b.Downtime == 0 {
+			return 0
+		}
+		return math.Inf(1)
+	}
+	return float64(b.Downtime) / float64(b.Budget)
+}
+
+// ErrorBudgetBurn returns the error budget burn over rolling windows of the
+// given length, for a target uptime percentage such as 99.9. The windows end
+// at start+window, start+window+step, and so on, up to end. It panics if
+// window or step is not positive or target is not in [0, 100].
+func (c *Calculator) ErrorBudgetBurn(start, end time.Time, window, step time.Duration, target float64) []Burn {
+	if window <= 0 || step <= 0 {
+		panic(fmt.Errorf("window %s and step %s must be positive", window, step))
+	}
+	if !(target >= 0 && target <= 100) {
+		panic(fmt.Errorf("target %v is not a percentage", target))
+	}
+	var result []Burn
+	for to := start.Add(window); !to.After(end); to = to.Add(step) {
+		r := c.Report(to.Add(-window), to)
+		result = append(result, Burn{
+			Start:    r.Start,
+			End:      r.End,
+			Budget:   time.Duration(float64(r.Eligible) * (100 - target) / 100),
+			Downtime: r.Downtime,
+		})
+	}
+	return result
+}
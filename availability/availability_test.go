@@ -0,0 +1,118 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package availability
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/go-intervals/timespanset"
+)
+
+func tz() *time.Location {
+	x, err := time.LoadLocation("PST8PDT")
+	if err != nil {
+		panic(fmt.Errorf("timezone not available: %v", err))
+	}
+	return x
+}
+
+func at(month time.Month, day, hour, minute int) time.Time {
+	return time.Date(2026, month, day, hour, minute, 0, 0, tz())
+}
+
+func spans(times ...time.Time) *timespanset.Set {
+	s := timespanset.Empty()
+	for i := 0; i < len(times); i += 2 {
+		s.Insert(times[i], times[i+1])
+	}
+	return s
+}
+
+func calculator() *Calculator {
+	outages := spans(
+		at(time.January, 10, 10, 0), at(time.January, 10, 12, 0),
+		at(time.January, 20, 0, 0), at(time.January, 20, 0, 30),
+		at(time.January, 31, 23, 0), at(time.February, 1, 1, 0),
+	)
+	maintenance := spans(at(time.January, 10, 11, 0), at(time.January, 10, 13, 0))
+	return New(outages, maintenance)
+}
+
+func TestMonthly(t *testing.T) {
+	got := calculator().Monthly(at(time.January, 15, 0, 0), at(time.March, 1, 0, 0), tz())
+	if len(got) != 2 {
+		t.Fatalf("Monthly() returned %d reports, want 2: %v", len(got), got)
+	}
+	jan, feb := got[0], got[1]
+	if !jan.Start.Equal(at(time.January, 15, 0, 0)) || !jan.End.Equal(at(time.February, 1, 0, 0)) {
+		t.Errorf("January report covers [%s, %s)", jan.Start, jan.End)
+	}
+	if jan.Downtime != 90*time.Minute || jan.Outages != 2 || jan.Longest != time.Hour {
+		t.Errorf("January report = %s, want 1h30m of downtime in 2 outages, the longest 1h", jan)
+	}
+	if feb.Downtime != time.Hour || feb.Outages != 1 || feb.Eligible != 28*24*time.Hour {
+		t.Errorf("February report = %s, want 1h of downtime in 1 outage over 28 days", feb)
+	}
+
+	full := calculator().Report(at(time.January, 1, 0, 0), at(time.February, 1, 0, 0))
+	if want := 31*24*time.Hour - 2*time.Hour; full.Eligible != want {
+		t.Errorf("Eligible = %s, want %s", full.Eligible, want)
+	}
+	if full.Downtime != 150*time.Minute || full.Outages != 3 || full.Longest != time.Hour {
+		t.Errorf("Report() = %s, want 2h30m of downtime in 3 outages, the longest 1h", full)
+	}
+	if got, want := full.MTTR(), 50*time.Minute; got != want {
+		t.Errorf("MTTR() = %s, want %s", got, want)
+	}
+	if got, want := full.MTBF(), (full.Eligible-full.Downtime)/3; got != want {
+		t.Errorf("MTBF() = %s, want %s", got, want)
+	}
+}
+
+func TestMaintenanceDuringOutage(t *testing.T) {
+	c := New(
+		spans(at(time.June, 1, 10, 0), at(time.June, 1, 12, 0)),
+		spans(at(time.June, 1, 10, 30), at(time.June, 1, 11, 0)),
+	)
+	r := c.Report(at(time.June, 1, 0, 0), at(time.June, 1, 0, 0).Add(100*time.Hour+30*time.Minute))
+	if r.Downtime != 90*time.Minute || r.Outages != 1 {
+		t.Errorf("Report() = %s, want 1h30m of downtime in 1 outage", r)
+	}
+	if got, want := r.Uptime(), 98.5; math.Abs(got-want) > 1e-9 {
+		t.Errorf("Uptime() = %v, want %v", got, want)
+	}
+	if got := New(timespanset.Empty(), nil).Report(at(time.June, 1, 0, 0), at(time.June, 2, 0, 0)); got.Uptime() != 100 || got.MTTR() != 0 || got.MTBF() != 0 {
+		t.Errorf("Report() with no outages = %s, MTTR %s, MTBF %s", got, got.MTTR(), got.MTBF())
+	}
+}
+
+func TestErrorBudgetBurn(t *testing.T) {
+	got := calculator().ErrorBudgetBurn(at(time.January, 1, 0, 0), at(time.January, 21, 0, 0), 10*24*time.Hour, 10*24*time.Hour, 99)
+	if len(got) != 2 {
+		t.Fatalf("ErrorBudgetBurn() returned %d windows, want 2", len(got))
+	}
+	if got[0].Budget != 2*time.Hour+22*time.Minute+48*time.Second || got[0].Downtime != time.Hour {
+		t.Errorf("first window: budget %s, downtime %s", got[0].Budget, got[0].Downtime)
+	}
+	if got, want := got[1].Fraction(), 0.5/2.4; math.Abs(got-want) > 1e-9 {
+		t.Errorf("second window: Fraction() = %v, want %v", got, want)
+	}
+	if got := (Burn{Downtime: time.Second}).Fraction(); !math.IsInf(got, 1) {
+		t.Errorf("Fraction() with no budget = %v, want +Inf", got)
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bitemporal records how a set of time spans changes over time, so
+// that it can answer questions such as "what did we believe at T1 about
+// coverage during [a, b)?".
+//
+// The spans of the set are in valid time: the time that the set describes.
+// Each change is recorded at a transaction time: the time at which the change
+// was believed. Every revision of the set is kept as an immutable snapshot.
+// Snapshots share the parts of their structure that a change does not touch,
+// so each change adds O(log n) storage for a set of n spans.
+//
+// The snapshots are kept in a persistent tree rather than in
+// intervalset.ImmutableSets. An ImmutableSet copies all of its intervals on
+// every Union, Sub or Intersect, so keeping every revision as one would cost
+// O(n) storage per change. timespanset.Sets are still the inputs to changes
+// and the results of queries.
+//
+// DISCLAIMER: This library is not yet stable, so expect breaking changes.
+package bitemporal
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/go-intervals/timespanset"
+)
+
+// Snapshot is an immutable revision of the set, as it was believed at a
+// transaction time. Snapshots are safe for concurrent use.
+type Snapshot struct {
+	tx   time.Time
+	root *node
+}
+
+var _ timespanset.Source = (*Snapshot)(nil)
+
+// TransactionTime returns the transaction time of the change that created the
+// snapshot. It is the zero time for the snapshot before any change.
+func (s *Snapshot) TransactionTime() time.Time {
+	return s.tx
+}
+
+// IntervalsBetween calls f with the start (inclusive) and end (exclusive) of
+// each valid-time span of the snapshot that overlaps [start, end), truncated
+// to [start, end). If f returns false, iteration ceases.
+func (s *Snapshot) IntervalsBetween(start, end time.Time, f timespanset.IntervalReceiver) {
+	walk(s.root, start, end, func(n *node) bool {
+		from, to := n.start, n.end
+		if from.Before(start) {
+			from = start
+		}
+		if to.After(end) {
+			to = end
+		}
+		return f(from, to)
+	})
+}
+
+// Contains reports whether [start, end) is entirely within the snapshot.
+func (s *Snapshot) Contains(start, end time.Time) bool {
+	if !start.Before(end) {
+		return true
+	}
+	n := floor(s.root, start)
+	return n != nil && !n.end.Before(end)
+}
+
+// Set returns a new set containing all the spans of the snapshot.
+func (s *Snapshot) Set() *timespanset.Set {
+	result := timespanset.Empty()
+	walkAll(s.root, func(n *node) {
+		result.Insert(n.start, n.end)
+	})
+	return result
+}
+
+func walkAll(n *node, f func(*node)) {
+	if n == nil {
+		return
+	}
+	walkAll(n.left, f)
+	f(n)
+	walkAll(n.right, f)
+}
+
+// Store holds the revisions of a set of valid-time spans, ordered by
+// transaction time. A Store is not safe for concurrent use, but the snapshots
+// it returns are.
+type Store struct {
+	revisions []*Snapshot
+}
+
+// New returns a store whose set is empty at all transaction times.
+func New() *Store {
+	return &Store{}
+}
+
+// latest returns the most recent snapshot.
+func (st *Store) latest() *Snapshot {
+	if len(st.revisions) == 0 {
+		return &Snapshot{}
+	}
+	return st.revisions[len(st.revisions)-1]
+}
+
+// update records a new revision at transaction time tx. Changes at the same
+// transaction time as the latest revision replace it.
+func (st *Store) update(tx time.Time, root *node) {
+	latest := st.latest()
+	if tx.Before(latest.tx) {
+		panic(fmt.Errorf("transaction time %s is before the latest transaction time %s", tx, latest.tx))
+	}
+	if n := len(st.revisions); n > 0 && tx.Equal(latest.tx) {
+		st.revisions[n-1] = &Snapshot{tx, root}
+		return
+	}
+	st.revisions = append(st.revisions, &Snapshot{tx, root})
+}
+
+// Add records that, from transaction time tx, the spans of s are believed to
+// be in the set. It panics if tx is before the transaction time of an earlier
+// change.
+func (st *Store) Add(tx time.Time, s *timespanset.Set) {
+	root := st.latest().root
+	first, last := s.Extent()
+	s.IntervalsBetween(first, last, func(start, end time.Time) bool {
+		root = insert(root, start, end)
+		return true
+	})
+	st.update(tx, root)
+}
+
+// Sub records that, from transaction time tx, the spans of s are believed not
+// to be in the set. It panics if tx is before the transaction time of an
+// earlier change.
+func (st *Store) Sub(tx time.Time, s *timespanset.Set) {
+	root := st.latest().root
+	first, last := s.Extent()
+	s.IntervalsBetween(first, last, func(start, end time.Time) bool {
+		root = remove(root, start, end)
+		return true
+	})
+	st.update(tx, root)
+}
+
+// Replace records that, from transaction time tx, the portion of the set
+// within [start, end) is believed to be the portion of s within [start, end).
+// It panics if end is before start or tx is before the transaction time of an
+// earlier change.
+func (st *Store) Replace(tx, start, end time.Time, s *timespanset.Set) {
+	if end.Before(start) {
+		panic(fmt.Errorf("end %s before start %s", end, start))
+	}
+	root := remove(st.latest().root, start, end)
+	s.IntervalsBetween(start, end, func(from, to time.Time) bool {
+		root = insert(root, from, to)
+		return true
+	})
+	st.update(tx, root)
+}
+
+// AsOf returns the snapshot of the set as it was believed at transaction time
+// tx, which reflects every change recorded at or before tx.
+func (st *Store) AsOf(tx time.Time) *Snapshot {
+	i := sort.Search(len(st.revisions), func(i int) bool { return st.revisions[i].tx.After(tx) })
+	if i == 0 {
+		return &Snapshot{}
+	}
+	return st.revisions[i-1]
+}
+
+// Between returns the portion of the set within the valid-time window
+// [start, end), as it was believed at transaction time tx.
+func (st *Store) Between(tx, start, end time.Time) *timespanset.Set {
+	return timespanset.Between(st.AsOf(tx), start, end)
+}
+
+// Revisions calls f with each snapshot of the set, in order of transaction
+// time. If f returns false, iteration ceases.
+func (st *Store) Revisions(f func(*Snapshot) bool) {
+	for _, s := range st.revisions {
+		if !f(s) {
+			return
+		}
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bitemporal
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/google/go-intervals/timespanset"
+)
+
+func day(d int) time.Time {
+	return time.Date(2015, time.June, d, 0, 0, 0, 0, time.UTC)
+}
+
+func spans(ss ...[2]int) *timespanset.Set {
+	s := timespanset.Empty()
+	for _, x := range ss {
+		s.Insert(day(x[0]), day(x[1]))
+	}
+	return s
+}
+
+func TestStore(t *testing.T) {
+	st := New()
+	st.Add(day(10), spans([2]int{1, 8}))
+	// A gap in coverage is discovered later.
+	st.Sub(day(12), spans([2]int{3, 4}))
+	// Coverage for [5, 8) is corrected.
+	st.Replace(day(15), day(5), day(8), spans([2]int{1, 2}, [2]int{6, 7}))
+
+	for _, tt := range []struct {
+		tx         time.Time
+		start, end time.Time
+		want       *timespanset.Set
+	}{
+		{day(9), day(1), day(30), spans()},
+		{day(11), day(2), day(5), spans([2]int{2, 5})},
+		{day(12), day(2), day(5), spans([2]int{2, 3}, [2]int{4, 5})},
+		{day(14), day(1), day(30), spans([2]int{1, 3}, [2]int{4, 8})},
+		{day(20), day(1), day(30), spans([2]int{1, 3}, [2]int{4, 5}, [2]int{6, 7})},
+	} {
+		if got := st.Between(tt.tx, tt.start, tt.end); got.String() != tt.want.String() {
+			t.Errorf("Between(%s, %s, %s) = %s, want %s", tt.tx, tt.start, tt.end, got, tt.want)
+		}
+	}
+
+	snap := st.AsOf(day(13))
+	if got, want := snap.TransactionTime(), day(12); !got.Equal(want) {
+		t.Errorf("TransactionTime() = %s, want %s", got, want)
+	}
+	if !snap.Contains(day(4), day(8)) || snap.Contains(day(2), day(5)) || !snap.Contains(day(3), day(3)) {
+		t.Errorf("Contains() gave unexpected results for %s", snap.Set())
+	}
+
+	// Changes at the same transaction time replace the latest revision.
+	st.Add(day(15), spans([2]int{20, 21}))
+	n := 0
+	st.Revisions(func(*Snapshot) bool {
+		n++
+		return true
+	})
+	if n != 3 {
+		t.Errorf("store has %d revisions, want 3", n)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Add() with an earlier transaction time did not panic")
+		}
+	}()
+	st.Add(day(14), spans([2]int{1, 2}))
+}
+
+func TestReplaceEmptyWindow(t *testing.T) {
+	hour := func(h int) time.Time { return day(1).Add(time.Duration(h) * time.Hour) }
+	st := New()
+	s := timespanset.Empty()
+	s.Insert(hour(0), hour(10))
+	st.Add(day(10), s)
+	st.Replace(day(11), hour(5), hour(5), timespanset.Empty())
+
+	snap := st.AsOf(day(11))
+	if !snap.Contains(hour(4), hour(6)) {
+		t.Errorf("Contains(4h, 6h) = false after replacing an empty window, set %s", snap.Set())
+	}
+	n := 0
+	snap.IntervalsBetween(hour(0), hour(10), func(start, end time.Time) bool {
+		n++
+		return true
+	})
+	if n != 1 {
+		t.Errorf("IntervalsBetween() returned %d spans, want 1", n)
+	}
+
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Errorf("Replace() of an inverted window did not panic")
+			return
+		}
+		want := fmt.Sprintf("end %s before start %s", hour(4), hour(6))
+		if err, ok := r.(error); !ok || err.Error() != want {
+			t.Errorf("Replace() panicked with %v, want %q", r, want)
+		}
+	}()
+	st.Replace(day(12), hour(6), hour(4), s)
+}
+
+func TestMatchesSet(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	st := New()
+	model := timespanset.Empty()
+	var want []string
+	for i := 0; i < 500; i++ {
+		a := day(1).Add(time.Duration(r.Intn(1000)) * time.Hour)
+		b := a.Add(time.Duration(r.Intn(50)+1) * time.Hour)
+		s := timespanset.Empty()
+		s.Insert(a, b)
+		tx := day(1).Add(time.Duration(i) * time.Minute)
+		switch r.Intn(3) {
+		case 0:
+			st.Add(tx, s)
+			model.Add(s)
+		case 1:
+			st.Sub(tx, s)
+			model.Sub(s)
+		default:
+			c := a.Add(-time.Duration(r.Intn(20)) * time.Hour)
+			d := b.Add(time.Duration(r.Intn(20)) * time.Hour)
+			st.Replace(tx, c, d, s)
+			window := timespanset.Empty()
+			window.Insert(c, d)
+			model.Sub(window)
+			model.Add(s)
+		}
+		want = append(want, model.String())
+	}
+	for i, w := range want {
+		tx := day(1).Add(time.Duration(i) * time.Minute)
+		if got := st.AsOf(tx).Set().String(); got != w {
+			t.Fatalf("AsOf(%s) = %s, want %s", tx, got, w)
+		}
+	}
+}
+
+func TestStructureSharing(t *testing.T) {
+	const n = 1000
+	st := New()
+	for i := 0; i < n; i++ {
+		start := day(1).Add(time.Duration(i) * time.Hour)
+		s := timespanset.Empty()
+		s.Insert(start, start.Add(time.Minute))
+		st.Add(start, s)
+	}
+	nodes := map[*node]bool{}
+	var visit func(*node)
+	visit = func(x *node) {
+		if x == nil || nodes[x] {
+			return
+		}
+		nodes[x] = true
+		visit(x.left)
+		visit(x.right)
+	}
+	st.Revisions(func(s *Snapshot) bool {
+		visit(s.root)
+		return true
+	})
+	// Without sharing, the revisions would hold n*(n+1)/2 nodes.
+	if len(nodes) > 20*n {
+		t.Errorf("%d revisions hold %d distinct nodes, want at most %d", n, len(nodes), 20*n)
+	}
+}
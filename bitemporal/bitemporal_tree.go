@@ -0,0 +1,158 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bitemporal
+
+import "time"
+
+// node is a node of a persistent treap of time spans, ordered by start time.
+// The spans of a tree never overlap or adjoin. Nodes are never modified after
+// they are created, so trees share all the nodes that an update does not
+// touch.
+type node struct {
+	start, end  time.Time
+	priority    uint64
+	left, right *node
+}
+
+// newNode returns a leaf node for [start, end). Its priority is a hash of the
+// start time, so trees built from the same spans have the same shape.
+func newNode(start, end time.Time) *node {
+	x := uint64(start.UnixNano()) + 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return &node{start: start, end: end, priority: x ^ (x >> 31)}
+}
+
+// with returns a copy of n with the given children.
+func (n *node) with(left, right *node) *node {
+	c := *n
+	c.left, c.right = left, right
+	return &c
+}
+
+// split divides the tree into the spans that start before t and the rest.
+// If inclusive is true, spans that start at t are also in the first tree.
+func split(n *node, t time.Time, inclusive bool) (*node, *node) {
+	if n == nil {
+		return nil, nil
+	}
+	if n.start.Before(t) || (inclusive && n.start.Equal(t)) {
+		l, r := split(n.right, t, inclusive)
+		return n.with(n.left, l), r
+	}
+	l, r := split(n.left, t, inclusive)
+	return l, n.with(r, n.right)
+}
+
+// merge returns the union of two trees, where every span of a is before every
+// span of b.
+func merge(a, b *node) *node {
+	switch {
+	case a == nil:
+		return b
+	case b == nil:
+		return a
+	case a.priority > b.priority:
+		return a.with(a.left, merge(a.right, b))
+	}
+	return b.with(merge(a, b.left), b.right)
+}
+
+// last returns the span with the latest start, or nil for an empty tree.
+func last(n *node) *node {
+	if n == nil {
+		return nil
+	}
+	for n.right != nil {
+		n = n.right
+	}
+	return n
+}
+
+// insert returns the tree with [start, end) added, coalescing the spans that
+// overlap or adjoin it. The tree is unchanged if [start, end) is empty.
+func insert(root *node, start, end time.Time) *node {
+	if !start.Before(end) {
+		return root
+	}
+	l, r := split(root, start, false)
+	if p := last(l); p != nil && !p.end.Before(start) {
+		start = p.start
+		if p.end.After(end) {
+			end = p.end
+		}
+		l, _ = split(l, p.start, false)
+	}
+	m, r := split(r, end, true)
+	if q := last(m); q != nil && q.end.After(end) {
+		end = q.end
+	}
+	return merge(merge(l, newNode(start, end)), r)
+}
+
+// remove returns the tree with [start, end) removed. The tree is unchanged if
+// [start, end) is empty.
+func remove(root *node, start, end time.Time) *node {
+	if !start.Before(end) {
+		return root
+	}
+	l, r := split(root, start, false)
+	var after *node
+	if p := last(l); p != nil && p.end.After(start) {
+		l, _ = split(l, p.start, false)
+		l = merge(l, newNode(p.start, start))
+		if p.end.After(end) {
+			after = newNode(end, p.end)
+		}
+	}
+	m, r := split(r, end, false)
+	if q := last(m); q != nil && q.end.After(end) {
+		after = newNode(end, q.end)
+	}
+	return merge(merge(l, after), r)
+}
+
+// walk calls f with each span of the tree that overlaps [start, end), in
+// increasing order. It returns false if f does.
+func walk(n *node, start, end time.Time, f func(*node) bool) bool {
+	if n == nil {
+		return true
+	}
+	if n.end.After(start) {
+		if !walk(n.left, start, end, f) {
+			return false
+		}
+		if n.start.Before(end) && !f(n) {
+			return false
+		}
+	}
+	if n.start.Before(end) {
+		return walk(n.right, start, end, f)
+	}
+	return true
+}
+
+// floor returns the span with the latest start at or before t, or nil.
+func floor(n *node, t time.Time) *node {
+	var result *node
+	for n != nil {
+		if n.start.After(t) {
+			n = n.left
+		} else {
+			result, n = n, n.right
+		}
+	}
+	return result
+}
@@ -0,0 +1,167 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package byterange is a finite set implementation for byte offsets within a
+// representation, with support for the HTTP Range and Content-Range header
+// fields described in RFC 9110.
+//
+// Like HTTP, this package describes byte ranges by their first and last
+// positions, both inclusive.
+//
+// DISCLAIMER: This library is not yet stable, so expect breaking changes.
+package byterange
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/google/go-intervals/intset"
+)
+
+// Range is a range of byte positions. Both First and Last are inclusive.
+type Range struct {
+	First, Last int64
+}
+
+// Length returns the number of bytes in the range.
+func (r Range) Length() int64 {
+	return r.Last - r.First + 1
+}
+
+// String returns the range in the form used by Range headers, "first-last".
+func (r Range) String() string {
+	return fmt.Sprintf("%d-%d", r.First, r.Last)
+}
+
+func (r Range) valid() bool {
+	return 0 <= r.First && r.First <= r.Last && r.Last < math.MaxInt64
+}
+
+// Set is a finite set of byte positions. Functions are provided for iterating
+// over the ranges of the set and performing set operations (intersection,
+// union, subtraction).
+type Set struct {
+	set *intset.Set
+}
+
+// Empty returns a new, empty Set.
+func Empty() *Set {
+	return &Set{intset.Empty()}
+}
+
+// Of returns a set containing the union of the given ranges. It panics if a
+// range is invalid.
+func Of(ranges ...Range) *Set {
+	s := Empty()
+	for _, r := range ranges {
+		s.Insert(r)
+	}
+	return s
+}
+
+// String returns the set as the value of a Range header, for example
+// "bytes=0-99,200-299". It returns "bytes=" for an empty set.
+func (s *Set) String() string {
+	var strs []string
+	for _, r := range s.Ranges() {
+		strs = append(strs, r.String())
+	}
+	return "bytes=" + strings.Join(strs, ",")
+}
+
+// Copy returns a copy of a set that may be mutated without affecting the original.
+func (s *Set) Copy() *Set {
+	return &Set{s.set.Copy()}
+}
+
+// Insert adds the bytes of r to the set. It returns a patch describing the
+// bytes that were not already in the set. It panics if r.First is negative, if
+// r.Last < r.First or if r.Last is math.MaxInt64.
+func (s *Set) Insert(r Range) *Patch {
+	if !r.valid() {
+		panic(fmt.Errorf("invalid byte range %v", r))
+	}
+	return fromIntPatch(s.set.Insert(r.First, r.Last+1))
+}
+
+// Add performs an in-place union of two sets. It returns a patch describing
+// the ranges that were actually added.
+func (s *Set) Add(b *Set) *Patch {
+	return fromIntPatch(s.set.Add(b.set))
+}
+
+// Sub performs an in-place subtraction of set b from set s. It returns a patch
+// describing the ranges that were actually removed.
+func (s *Set) Sub(b *Set) *Patch {
+	return fromIntPatch(s.set.Sub(b.set))
+}
+
+// Intersect performs an in-place intersection of sets s and b. It returns a
+// patch describing the ranges that were actually removed.
+func (s *Set) Intersect(b *Set) *Patch {
+	return fromIntPatch(s.set.Intersect(b.set))
+}
+
+// Empty reports whether the set has no members.
+func (s *Set) Empty() bool {
+	return s.set.Empty()
+}
+
+// Contains reports whether the byte at position offset is a member of the set.
+func (s *Set) Contains(offset int64) bool {
+	return s.set.Contains(offset)
+}
+
+// ContainsRange reports whether every byte of r is a member of the set.
+func (s *Set) ContainsRange(r Range) bool {
+	if !r.valid() {
+		return false
+	}
+	return s.set.ContainsRange(r.First, r.Last+1)
+}
+
+// Length returns the number of bytes in the set.
+func (s *Set) Length() int64 {
+	return int64(s.set.Cardinality())
+}
+
+// Ranges returns the ranges of the set in increasing order.
+func (s *Set) Ranges() []Range {
+	var result []Range
+	s.set.Intervals(func(lo, hi int64) bool {
+		result = append(result, Range{lo, hi - 1})
+		return true
+	})
+	return result
+}
+
+// Complete reports whether the set contains every byte of a representation of
+// the given length.
+func (s *Set) Complete(length int64) bool {
+	return s.set.ContainsRange(0, length)
+}
+
+// Missing returns the bytes selected by specs from a representation of the
+// given length that are not members of s. For example, a cache holding the
+// bytes s can use Missing to find what to fetch in order to answer a request.
+// It returns ErrUnsatisfiable if none of specs can be satisfied.
+func (s *Set) Missing(specs []Spec, length int64) (*Set, error) {
+	want, err := Resolve(specs, length)
+	if err != nil {
+		return nil, err
+	}
+	want.Sub(s)
+	return want, nil
+}
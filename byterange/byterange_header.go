@@ -0,0 +1,238 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package byterange
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ErrUnsatisfiable is returned by Resolve when none of the requested ranges
+// overlap the representation. An HTTP server should respond with status 416
+// (Range Not Satisfiable).
+var ErrUnsatisfiable = errors.New("byterange: range not satisfiable")
+
+// Spec is a single range of a Range header before it has been resolved against
+// the length of a representation.
+type Spec struct {
+	// First and Last are the inclusive positions of an int-range such as
+	// "0-499". Last is -1 if the range extends to the end of the
+	// representation, as in "500-".
+	First, Last int64
+
+	// Suffix is true for a suffix-range such as "-500", which selects the
+	// final SuffixLength bytes of the representation. First and Last are
+	// ignored.
+	Suffix       bool
+	SuffixLength int64
+}
+
+// String returns the spec as it appears in a Range header.
+func (sp Spec) String() string {
+	switch {
+	case sp.Suffix:
+		return fmt.Sprintf("-%d", sp.SuffixLength)
+	case sp.Last < 0:
+		return fmt.Sprintf("%d-", sp.First)
+	}
+	return fmt.Sprintf("%d-%d", sp.First, sp.Last)
+}
+
+// resolve returns the bytes selected by the spec from a representation of the
+// given length. The second return value is false if the spec is not
+// satisfiable.
+func (sp Spec) resolve(length int64) (Range, bool) {
+	if sp.Suffix {
+		if sp.SuffixLength <= 0 || length <= 0 {
+			return Range{}, false
+		}
+		if sp.SuffixLength >= length {
+			return Range{0, length - 1}, true
+		}
+		return Range{length - sp.SuffixLength, length - 1}, true
+	}
+	if sp.First >= length {
+		return Range{}, false
+	}
+	if sp.Last < 0 || sp.Last >= length {
+		return Range{sp.First, length - 1}, true
+	}
+	return Range{sp.First, sp.Last}, true
+}
+
+// ParseRange parses the value of a Range header such as
+// "bytes=0-499, -500, 1000-". The range unit must be "bytes".
+func ParseRange(header string) ([]Spec, error) {
+	unit, set, ok := strings.Cut(strings.TrimSpace(header), "=")
+	if !ok || !strings.EqualFold(strings.TrimSpace(unit), "bytes") {
+		return nil, fmt.Errorf("byterange: unsupported Range header %q", header)
+	}
+	var specs []Spec
+	for _, item := range strings.Split(set, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			// RFC 9110 allows empty list elements.
+			continue
+		}
+		sp, err := parseSpec(item)
+		if err != nil {
+			return nil, err
+		}
+		specs = append(specs, sp)
+	}
+	if len(specs) == 0 {
+		return nil, fmt.Errorf("byterange: no ranges in Range header %q", header)
+	}
+	return specs, nil
+}
+
+func parseSpec(item string) (Spec, error) {
+	first, last, ok := strings.Cut(item, "-")
+	if !ok {
+		return Spec{}, fmt.Errorf("byterange: invalid range %q", item)
+	}
+	if first == "" {
+		n, err := parsePos(last)
+		if err != nil {
+			return Spec{}, err
+		}
+		return Spec{Suffix: true, SuffixLength: n}, nil
+	}
+	sp := Spec{Last: -1}
+	var err error
+	if sp.First, err = parsePos(first); err != nil {
+		return Spec{}, err
+	}
+	if last == "" {
+		return sp, nil
+	}
+	if sp.Last, err = parsePos(last); err != nil {
+		return Spec{}, err
+	}
+	if sp.Last < sp.First {
+		return Spec{}, fmt.Errorf("byterange: last position before first in range %q", item)
+	}
+	return sp, nil
+}
+
+// parsePos parses a non-negative decimal byte position. math.MaxInt64 is
+// rejected so that the position after it is representable.
+func parsePos(text string) (int64, error) {
+	for _, c := range text {
+		if c < '0' || c > '9' {
+			return 0, fmt.Errorf("byterange: invalid byte position %q", text)
+		}
+	}
+	n, err := strconv.ParseInt(text, 10, 64)
+	if err != nil || n == math.MaxInt64 {
+		return 0, fmt.Errorf("byterange: invalid byte position %q", text)
+	}
+	return n, nil
+}
+
+// FormatRange returns the value of a Range header requesting specs.
+func FormatRange(specs []Spec) string {
+	var strs []string
+	for _, sp := range specs {
+		strs = append(strs, sp.String())
+	}
+	return "bytes=" + strings.Join(strs, ",")
+}
+
+// Resolve returns the bytes selected by specs from a representation of the
+// given length. Overlapping and adjoining ranges are coalesced, and ranges that
+// begin beyond the end of the representation are ignored. If no range is
+// satisfiable, Resolve returns ErrUnsatisfiable.
+func Resolve(specs []Spec, length int64) (*Set, error) {
+	s := Empty()
+	for _, sp := range specs {
+		if r, ok := sp.resolve(length); ok {
+			s.Insert(r)
+		}
+	}
+	if s.Empty() {
+		return nil, ErrUnsatisfiable
+	}
+	return s, nil
+}
+
+// ContentRange is the value of a Content-Range header.
+type ContentRange struct {
+	// Range is the range of bytes enclosed in the message. It is ignored if
+	// Unsatisfied is true.
+	Range Range
+
+	// Unsatisfied is true for the "*/length" form sent with a 416 (Range Not
+	// Satisfiable) response.
+	Unsatisfied bool
+
+	// CompleteLength is the length of the whole representation, or -1 if it is
+	// unknown.
+	CompleteLength int64
+}
+
+// String returns the value of the Content-Range header, for example
+// "bytes 0-499/1234".
+func (cr ContentRange) String() string {
+	complete := "*"
+	if cr.CompleteLength >= 0 {
+		complete = strconv.FormatInt(cr.CompleteLength, 10)
+	}
+	if cr.Unsatisfied {
+		return "bytes */" + complete
+	}
+	return fmt.Sprintf("bytes %s/%s", cr.Range, complete)
+}
+
+// ParseContentRange parses the value of a Content-Range header such as
+// "bytes 0-499/1234", "bytes 0-499/*" or "bytes */1234".
+func ParseContentRange(header string) (ContentRange, error) {
+	invalid := fmt.Errorf("byterange: invalid Content-Range header %q", header)
+	unit, resp, ok := strings.Cut(strings.TrimSpace(header), " ")
+	if !ok || !strings.EqualFold(unit, "bytes") {
+		return ContentRange{}, invalid
+	}
+	rng, complete, ok := strings.Cut(strings.TrimSpace(resp), "/")
+	if !ok {
+		return ContentRange{}, invalid
+	}
+	cr := ContentRange{CompleteLength: -1}
+	if complete != "*" {
+		n, err := parsePos(complete)
+		if err != nil {
+			return ContentRange{}, invalid
+		}
+		cr.CompleteLength = n
+	}
+	if rng == "*" {
+		if cr.CompleteLength < 0 {
+			return ContentRange{}, invalid
+		}
+		cr.Unsatisfied = true
+		return cr, nil
+	}
+	sp, err := parseSpec(rng)
+	if err != nil || sp.Suffix || sp.Last < 0 {
+		return ContentRange{}, invalid
+	}
+	cr.Range = Range{sp.First, sp.Last}
+	if cr.CompleteLength >= 0 && cr.Range.Last >= cr.CompleteLength {
+		return ContentRange{}, invalid
+	}
+	return cr, nil
+}
@@ -0,0 +1,188 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package byterange
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRange(t *testing.T) {
+	for _, tt := range []struct {
+		header string
+		want   []Spec
+	}{
+		{"bytes=0-499", []Spec{{First: 0, Last: 499}}},
+		{"bytes=-500", []Spec{{Suffix: true, SuffixLength: 500}}},
+		{"Bytes=9500-", []Spec{{First: 9500, Last: -1}}},
+		{"bytes=0-0, -1 ,, 10-", []Spec{{First: 0, Last: 0}, {Suffix: true, SuffixLength: 1}, {First: 10, Last: -1}}},
+	} {
+		got, err := ParseRange(tt.header)
+		if err != nil {
+			t.Errorf("ParseRange(%q) = %v", tt.header, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseRange(%q) = %v, want %v", tt.header, got, tt.want)
+		}
+	}
+
+	for _, header := range []string{
+		"",
+		"bytes=",
+		"items=0-1",
+		"bytes=5-1",
+		"bytes=1",
+		"bytes=+1-2",
+		"bytes=0-9223372036854775807",
+		"bytes=-",
+	} {
+		if _, err := ParseRange(header); err == nil {
+			t.Errorf("ParseRange(%q) = nil error, want error", header)
+		}
+	}
+
+	specs := []Spec{{First: 0, Last: 499}, {Suffix: true, SuffixLength: 500}, {First: 9500, Last: -1}}
+	if got, want := FormatRange(specs), "bytes=0-499,-500,9500-"; got != want {
+		t.Errorf("FormatRange() = %q, want %q", got, want)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	for _, tt := range []struct {
+		header string
+		length int64
+		want   []Range
+	}{
+		{"bytes=0-499", 10000, []Range{{0, 499}}},
+		{"bytes=-500", 10000, []Range{{9500, 9999}}},
+		{"bytes=-500", 100, []Range{{0, 99}}},
+		{"bytes=9500-", 10000, []Range{{9500, 9999}}},
+		{"bytes=0-99999", 10000, []Range{{0, 9999}}},
+		{"bytes=0-10,5-20,21-30,50-60", 10000, []Range{{0, 30}, {50, 60}}},
+		{"bytes=20000-,0-9", 10000, []Range{{0, 9}}},
+	} {
+		specs, err := ParseRange(tt.header)
+		if err != nil {
+			t.Fatalf("ParseRange(%q) = %v", tt.header, err)
+		}
+		s, err := Resolve(specs, tt.length)
+		if err != nil {
+			t.Errorf("Resolve(%q, %d) = %v", tt.header, tt.length, err)
+			continue
+		}
+		if got := s.Ranges(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Resolve(%q, %d) = %v, want %v", tt.header, tt.length, got, tt.want)
+		}
+	}
+
+	for _, tt := range []struct {
+		header string
+		length int64
+	}{
+		{"bytes=10000-", 10000},
+		{"bytes=-0", 10000},
+		{"bytes=-10", 0},
+	} {
+		specs, _ := ParseRange(tt.header)
+		if _, err := Resolve(specs, tt.length); err != ErrUnsatisfiable {
+			t.Errorf("Resolve(%q, %d) = %v, want ErrUnsatisfiable", tt.header, tt.length, err)
+		}
+	}
+}
+
+func TestMissing(t *testing.T) {
+	fetched := Of(Range{0, 99}, Range{200, 299})
+	specs, _ := ParseRange("bytes=50-249,-100")
+
+	missing, err := fetched.Missing(specs, 1000)
+	if err != nil {
+		t.Fatalf("Missing() = %v", err)
+	}
+	if got, want := missing.String(), "bytes=100-199,900-999"; got != want {
+		t.Errorf("Missing() = %s, want %s", got, want)
+	}
+	if got, want := missing.Length(), int64(200); got != want {
+		t.Errorf("Length() = %d, want %d", got, want)
+	}
+
+	fetched.Add(missing)
+	if fetched.Complete(1000) {
+		t.Errorf("Complete(1000) = true for %v", fetched)
+	}
+	fetched.Insert(Range{300, 899})
+	if !fetched.Complete(1000) || !fetched.ContainsRange(Range{0, 999}) || fetched.Contains(1000) {
+		t.Errorf("unexpected membership for %v", fetched)
+	}
+}
+
+func TestPatches(t *testing.T) {
+	s := Of(Range{0, 99})
+	if got, want := s.Insert(Range{50, 149}), (&Patch{Added: []Range{{100, 149}}}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Insert() = %+v, want %+v", got, want)
+	}
+	if got, want := s.Sub(Of(Range{10, 19}, Range{140, 200})), (&Patch{Removed: []Range{{10, 19}, {140, 149}}}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Sub() = %+v, want %+v", got, want)
+	}
+	if got, want := s.Intersect(Of(Range{5, 500})), (&Patch{Removed: []Range{{0, 4}}}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Intersect() = %+v, want %+v", got, want)
+	}
+	if got, want := s.Add(Of(Range{0, 9})), (&Patch{Added: []Range{{0, 4}}}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Add() = %+v, want %+v", got, want)
+	}
+
+	old, updated := Of(Range{0, 99}), Of(Range{50, 199})
+	NewPatch(old, updated).Apply(old)
+	if got, want := old.String(), updated.String(); got != want {
+		t.Errorf("NewPatch(old, updated).Apply(old) = %s, want %s", got, want)
+	}
+}
+
+func TestContentRange(t *testing.T) {
+	for _, tt := range []struct {
+		header string
+		want   ContentRange
+	}{
+		{"bytes 0-499/1234", ContentRange{Range: Range{0, 499}, CompleteLength: 1234}},
+		{"bytes 42-1233/*", ContentRange{Range: Range{42, 1233}, CompleteLength: -1}},
+		{"bytes */1234", ContentRange{Unsatisfied: true, CompleteLength: 1234}},
+	} {
+		got, err := ParseContentRange(tt.header)
+		if err != nil {
+			t.Errorf("ParseContentRange(%q) = %v", tt.header, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseContentRange(%q) = %+v, want %+v", tt.header, got, tt.want)
+		}
+		if got.String() != tt.header {
+			t.Errorf("String() = %q, want %q", got.String(), tt.header)
+		}
+	}
+
+	for _, header := range []string{
+		"",
+		"bytes 0-499",
+		"bytes */*",
+		"bytes 0-/100",
+		"bytes -5/100",
+		"bytes 0-100/100",
+		"items 0-1/2",
+	} {
+		if _, err := ParseContentRange(header); err == nil {
+			t.Errorf("ParseContentRange(%q) = nil error, want error", header)
+		}
+	}
+}
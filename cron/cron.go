@@ -0,0 +1,242 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cron parses cron expressions and expands windows that open at each
+// activation of a cron schedule, such as "0 2 * * SUN for 3h", into time
+// ranges.
+//
+// A Window is a timespanset.Source, so its ranges are computed lazily for the
+// time range being queried.
+//
+// DISCLAIMER: This library is not yet stable, so expect breaking changes.
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed cron expression.
+type Schedule struct {
+	second, minute, hour, dom, month, dow uint64
+
+	// If both the day of month and day of week fields are restricted, a day
+	// matches if either field matches.
+	domRestricted, dowRestricted bool
+
+	loc *time.Location
+}
+
+type field struct {
+	name     string
+	min, max int
+	names    []string
+
+	// question is true if "?" may be used for "*".
+	question bool
+}
+
+var (
+	secondField = field{name: "second", min: 0, max: 59}
+	minuteField = field{name: "minute", min: 0, max: 59}
+	hourField   = field{name: "hour", min: 0, max: 23}
+	domField    = field{name: "day of month", min: 1, max: 31, question: true}
+	monthField  = field{name: "month", min: 1, max: 12, names: []string{
+		"", "JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}}
+	// Both 0 and 7 are Sunday.
+	dowField = field{name: "day of week", min: 0, max: 7, question: true, names: []string{
+		"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}}
+)
+
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// ParseSchedule parses a cron expression, which has five fields (minute, hour,
+// day of month, month and day of week) or six fields with a leading second.
+// Each field may be "*", a value, a range "a-b" or a comma-separated list of
+// those, and values and "*" may be followed by a step such as "*/15" or
+// "1-10/3". Months and days of the week may be given by their three-letter
+// English names, and "?" may be used for "*" in the day fields. The macros
+// @yearly, @annually, @monthly, @weekly, @daily, @midnight and @hourly are also
+// accepted.
+//
+// Activations are computed in wall-clock time in loc. An activation whose
+// wall-clock time is skipped by a daylight saving transition happens at the
+// instant chosen by time.Date, and one whose wall-clock time is repeated
+// happens once.
+func ParseSchedule(expr string, loc *time.Location) (*Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	if m, ok := macros[strings.ToLower(expr)]; ok {
+		expr = m
+	}
+	fields := strings.Fields(expr)
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, fmt.Errorf("cron: expression %q must have 5 or 6 fields", expr)
+	}
+	s := &Schedule{loc: loc}
+	var err error
+	for i, p := range []struct {
+		f    field
+		bits *uint64
+	}{
+		{secondField, &s.second},
+		{minuteField, &s.minute},
+		{hourField, &s.hour},
+		{domField, &s.dom},
+		{monthField, &s.month},
+		{dowField, &s.dow},
+	} {
+		if *p.bits, err = p.f.parse(fields[i]); err != nil {
+			return nil, fmt.Errorf("cron: invalid expression %q: %v", expr, err)
+		}
+	}
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	s.domRestricted = !strings.HasPrefix(fields[3], "*") && fields[3] != "?"
+	s.dowRestricted = !strings.HasPrefix(fields[5], "*") && fields[5] != "?"
+	return s, nil
+}
+
+// parse returns a bit set of the values matched by text.
+func (f field) parse(text string) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(text, ",") {
+		rng, stepText, hasStep := strings.Cut(item, "/")
+		lo, hi := f.min, f.max
+		switch {
+		case rng == "*" || rng == "?" && f.question:
+		case strings.Contains(rng, "-"):
+			a, b, _ := strings.Cut(rng, "-")
+			var err error
+			if lo, err = f.value(a); err != nil {
+				return 0, err
+			}
+			if hi, err = f.value(b); err != nil {
+				return 0, err
+			}
+			if hi < lo {
+				return 0, fmt.Errorf("invalid %s range %q", f.name, rng)
+			}
+		default:
+			var err error
+			if lo, err = f.value(rng); err != nil {
+				return 0, err
+			}
+			if !hasStep {
+				hi = lo
+			}
+		}
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepText); err != nil || step < 1 {
+				return 0, fmt.Errorf("invalid %s step %q", f.name, stepText)
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func (f field) value(text string) (int, error) {
+	for i, name := range f.names {
+		if name != "" && strings.EqualFold(text, name) {
+			return i, nil
+		}
+	}
+	v, err := strconv.Atoi(text)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("invalid %s %q", f.name, text)
+	}
+	return v, nil
+}
+
+func has(bits uint64, v int) bool {
+	return bits&(1<<uint(v)) != 0
+}
+
+// matchDay reports whether the civil date of c matches the day of month, month
+// and day of week fields.
+func (s *Schedule) matchDay(c time.Time) bool {
+	dom, dow := has(s.dom, c.Day()), has(s.dow, int(c.Weekday()))
+	if s.domRestricted && s.dowRestricted {
+		return dom || dow
+	}
+	return dom && dow
+}
+
+// maxSearchYears bounds the search for the next activation. Every satisfiable
+// expression, including one for February 29, activates within this many
+// years.
+const maxSearchYears = 9
+
+// Next returns the first activation of the schedule after t. It returns the
+// zero time if the schedule never activates, as for "0 0 30 2 *".
+func (s *Schedule) Next(t time.Time) time.Time {
+	// Search in civil time, represented as a time in UTC, starting at the next
+	// second of t's wall-clock time in loc. Instants that fall back in time
+	// because of a daylight saving transition are skipped.
+	w := t.In(s.loc)
+	c := time.Date(w.Year(), w.Month(), w.Day(), w.Hour(), w.Minute(), w.Second(), 0, time.UTC)
+	for {
+		c = s.nextCivil(c)
+		if c.IsZero() {
+			return time.Time{}
+		}
+		next := time.Date(c.Year(), c.Month(), c.Day(), c.Hour(), c.Minute(), c.Second(), 0, s.loc)
+		if next.After(t) {
+			return next
+		}
+	}
+}
+
+// nextCivil returns the first civil time after c that matches the schedule, or
+// the zero time if there is none within maxSearchYears.
+func (s *Schedule) nextCivil(c time.Time) time.Time {
+	limit := c.AddDate(maxSearchYears, 0, 0)
+	c = c.Truncate(time.Second).Add(time.Second)
+	for c.Before(limit) {
+		switch {
+		case !has(s.month, int(c.Month())):
+			c = time.Date(c.Year(), c.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		case !s.matchDay(c):
+			c = time.Date(c.Year(), c.Month(), c.Day()+1, 0, 0, 0, 0, time.UTC)
+		case !has(s.hour, c.Hour()):
+			c = c.Truncate(time.Hour).Add(time.Hour)
+		case !has(s.minute, c.Minute()):
+			c = c.Truncate(time.Minute).Add(time.Minute)
+		case !has(s.second, c.Second()):
+			c = c.Add(time.Second)
+		default:
+			return c
+		}
+	}
+	return time.Time{}
+}
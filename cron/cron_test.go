@@ -0,0 +1,160 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cron
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-intervals/timespanset"
+)
+
+func tz() *time.Location {
+	x, err := time.LoadLocation("PST8PDT")
+	if err != nil {
+		panic(fmt.Errorf("timezone not available: %v", err))
+	}
+	return x
+}
+
+func at(year int, month time.Month, day, hour, min, sec int) time.Time {
+	return time.Date(year, month, day, hour, min, sec, 0, tz())
+}
+
+const layout = "Mon 2006-01-02 15:04:05 MST"
+
+func TestNext(t *testing.T) {
+	// Thursday, June 4, 2026.
+	from := at(2026, time.June, 4, 10, 30, 0)
+	for _, tt := range []struct {
+		expr string
+		want []string
+	}{
+		{"*/20 * * * *", []string{"Thu 2026-06-04 10:40:00 PDT", "Thu 2026-06-04 11:00:00 PDT", "Thu 2026-06-04 11:20:00 PDT"}},
+		{"30 10 * * *", []string{"Fri 2026-06-05 10:30:00 PDT", "Sat 2026-06-06 10:30:00 PDT", "Sun 2026-06-07 10:30:00 PDT"}},
+		{"0 2 * * SUN", []string{"Sun 2026-06-07 02:00:00 PDT", "Sun 2026-06-14 02:00:00 PDT", "Sun 2026-06-21 02:00:00 PDT"}},
+		{"0 0 1,15 * 7", []string{"Sun 2026-06-07 00:00:00 PDT", "Sun 2026-06-14 00:00:00 PDT", "Mon 2026-06-15 00:00:00 PDT"}},
+		{"0 9 ? JAN-MAR/2 MON-FRI", []string{"Fri 2027-01-01 09:00:00 PST", "Mon 2027-01-04 09:00:00 PST", "Tue 2027-01-05 09:00:00 PST"}},
+		{"15,45 30 10 * * *", []string{"Thu 2026-06-04 10:30:15 PDT", "Thu 2026-06-04 10:30:45 PDT", "Fri 2026-06-05 10:30:15 PDT"}},
+		{"0 0 29 2 *", []string{"Tue 2028-02-29 00:00:00 PST", "Sun 2032-02-29 00:00:00 PST", "Fri 2036-02-29 00:00:00 PST"}},
+		{"@monthly", []string{"Wed 2026-07-01 00:00:00 PDT", "Sat 2026-08-01 00:00:00 PDT", "Tue 2026-09-01 00:00:00 PDT"}},
+	} {
+		s, err := ParseSchedule(tt.expr, tz())
+		if err != nil {
+			t.Errorf("ParseSchedule(%q) = %v", tt.expr, err)
+			continue
+		}
+		var got []string
+		for next := s.Next(from); len(got) < 3; next = s.Next(next) {
+			got = append(got, next.Format(layout))
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%q: Next() = %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+
+	s, _ := ParseSchedule("0 0 30 2 *", tz())
+	if got := s.Next(from); !got.IsZero() {
+		t.Errorf("Next() = %s, want zero time for an impossible schedule", got)
+	}
+}
+
+func TestNextDaylightSaving(t *testing.T) {
+	s, _ := ParseSchedule("30 1 * * *", tz())
+	// On November 1, 2026, 01:30 happens twice, but the job runs once.
+	var got []string
+	for next := s.Next(at(2026, time.October, 31, 12, 0, 0)); len(got) < 3; next = s.Next(next) {
+		got = append(got, next.Format(layout))
+	}
+	want := []string{"Sun 2026-11-01 01:30:00 PDT", "Mon 2026-11-02 01:30:00 PST", "Tue 2026-11-03 01:30:00 PST"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Next() = %v, want %v", got, want)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"* * * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"? * * * *",
+		"* * * FOO *",
+	} {
+		if _, err := ParseSchedule(expr, time.UTC); err == nil {
+			t.Errorf("ParseSchedule(%q) = nil error, want error", expr)
+		}
+	}
+	for _, text := range []string{"0 2 * * SUN", "0 2 * * SUN for", "0 2 * * SUN for -1h", "0 2 * * FUNDAY for 1h"} {
+		if _, err := Parse(text, time.UTC); err == nil {
+			t.Errorf("Parse(%q) = nil error, want error", text)
+		}
+	}
+}
+
+func TestWindow(t *testing.T) {
+	w, err := Parse("0 2 * * SUN for 3h", tz())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		t    time.Time
+		want bool
+	}{
+		{at(2026, time.June, 7, 1, 59, 59), false},
+		{at(2026, time.June, 7, 2, 0, 0), true},
+		{at(2026, time.June, 7, 4, 59, 59), true},
+		{at(2026, time.June, 7, 5, 0, 0), false},
+		{at(2026, time.June, 8, 3, 0, 0), false},
+	} {
+		if got := w.Contains(tt.t); got != tt.want {
+			t.Errorf("Contains(%s) = %v, want %v", tt.t.Format(layout), got, tt.want)
+		}
+	}
+
+	start, end, ok := w.NextWindow(at(2026, time.June, 4, 10, 30, 0))
+	if !ok || !start.Equal(at(2026, time.June, 7, 2, 0, 0)) || !end.Equal(at(2026, time.June, 7, 5, 0, 0)) {
+		t.Errorf("NextWindow() = %s, %s, %v", start.Format(layout), end.Format(layout), ok)
+	}
+	start, end, ok = w.NextWindow(at(2026, time.June, 7, 3, 0, 0))
+	if !ok || !start.Equal(at(2026, time.June, 7, 3, 0, 0)) || !end.Equal(at(2026, time.June, 7, 5, 0, 0)) {
+		t.Errorf("NextWindow() inside a window = %s, %s, %v", start.Format(layout), end.Format(layout), ok)
+	}
+
+	set := timespanset.Between(w, at(2026, time.June, 7, 3, 0, 0), at(2026, time.June, 21, 3, 0, 0))
+	want := timespanset.Empty()
+	want.Insert(at(2026, time.June, 7, 3, 0, 0), at(2026, time.June, 7, 5, 0, 0))
+	want.Insert(at(2026, time.June, 14, 2, 0, 0), at(2026, time.June, 14, 5, 0, 0))
+	want.Insert(at(2026, time.June, 21, 2, 0, 0), at(2026, time.June, 21, 3, 0, 0))
+	if set.String() != want.String() {
+		t.Errorf("Between() = %s, want %s", set, want)
+	}
+
+	// Overlapping windows are combined. Activations are three hours apart when
+	// clocks fall back, so shorter windows would leave a gap.
+	w, _ = Parse("0 */2 * * * for 3h", tz())
+	start, end, _ = w.NextWindow(at(2026, time.June, 4, 10, 30, 0))
+	if !start.Equal(at(2026, time.June, 4, 10, 30, 0)) || !end.Equal(at(2026, time.June, 4, 10, 30, 0).Add(366*24*time.Hour)) {
+		t.Errorf("NextWindow() = %s, %s, want a range of 366 days", start.Format(layout), end.Format(layout))
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cron
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/go-intervals/timespanset"
+)
+
+// Window is a range of time of a fixed duration that opens at each activation
+// of a schedule, such as a maintenance window.
+type Window struct {
+	Schedule *Schedule
+	Duration time.Duration
+}
+
+var _ timespanset.Source = (*Window)(nil)
+
+// Parse parses a window in the form "<cron expression> for <duration>", such
+// as "0 2 * * SUN for 3h". The cron expression is parsed by ParseSchedule and
+// the duration by time.ParseDuration.
+func Parse(text string, loc *time.Location) (*Window, error) {
+	expr, d, ok := strings.Cut(text, " for ")
+	if !ok {
+		return nil, fmt.Errorf("cron: window %q has no duration", text)
+	}
+	s, err := ParseSchedule(expr, loc)
+	if err != nil {
+		return nil, err
+	}
+	duration, err := time.ParseDuration(strings.TrimSpace(d))
+	if err != nil || duration <= 0 {
+		return nil, fmt.Errorf("cron: invalid window duration %q", d)
+	}
+	return &Window{s, duration}, nil
+}
+
+// IntervalsBetween calls f with the start (inclusive) and end (exclusive) of
+// each range of time covered by the window, truncated to [start, end).
+// Overlapping and adjoining windows are combined. If f returns false,
+// iteration ceases.
+func (w *Window) IntervalsBetween(start, end time.Time, f timespanset.IntervalReceiver) {
+	var curStart, curEnd time.Time
+	// Windows that open after start-Duration end after start.
+	for a := w.Schedule.Next(start.Add(-w.Duration)); !a.IsZero() && a.Before(end); a = w.Schedule.Next(a) {
+		s, e := a, a.Add(w.Duration)
+		if s.Before(start) {
+			s = start
+		}
+		if !curEnd.IsZero() && !s.After(curEnd) {
+			curEnd = e
+			continue
+		}
+		if !curEnd.IsZero() && !f(curStart, minTime(curEnd, end)) {
+			return
+		}
+		curStart, curEnd = s, e
+	}
+	if !curEnd.IsZero() {
+		f(curStart, minTime(curEnd, end))
+	}
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+// Contains reports whether t is within an open window.
+func (w *Window) Contains(t time.Time) bool {
+	a := w.Schedule.Next(t.Add(-w.Duration))
+	return !a.IsZero() && !a.After(t)
+}
+
+// maxMerge bounds the length of the range returned by NextWindow, since a
+// window that is longer than the gap between activations never closes.
+const maxMerge = 366 * 24 * time.Hour
+
+// NextWindow returns the range of time from t, or from the first window
+// opening after t, until the windows close. Overlapping and adjoining windows
+// are combined, up to a total length of 366 days. The last return value is
+// false if the schedule never activates again.
+func (w *Window) NextWindow(t time.Time) (start, end time.Time, ok bool) {
+	a := w.Schedule.Next(t.Add(-w.Duration))
+	if a.IsZero() {
+		return time.Time{}, time.Time{}, false
+	}
+	start = a
+	if start.Before(t) {
+		start = t
+	}
+	w.IntervalsBetween(start, start.Add(maxMerge), func(s, e time.Time) bool {
+		end = e
+		return false
+	})
+	return start, end, true
+}
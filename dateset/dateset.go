@@ -0,0 +1,189 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dateset is a finite set implementation for civil dates, such as
+// vacation and holiday calendars.
+//
+// Unlike timespanset, a dateset.Set does not depend on a time.Location: "all
+// of June" is the same set of dates everywhere. ToTimespanSet converts a set
+// to the instants covered by its dates in a particular location, taking
+// daylight saving transitions into account.
+//
+// Like calendars, this package describes ranges of dates by their first and
+// last dates, both inclusive.
+//
+// DISCLAIMER: This library is not yet stable, so expect breaking changes.
+package dateset
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/go-intervals/intset"
+	"github.com/google/go-intervals/timespanset"
+)
+
+// Set is a finite set of dates. Functions are provided for iterating over the
+// dates of the set and performing set operations (intersection, union,
+// subtraction).
+type Set struct {
+	set *intset.Set
+}
+
+// Empty returns a new, empty Set.
+func Empty() *Set {
+	return &Set{intset.Empty()}
+}
+
+// String returns a human readable version of the set, for example
+// "{2026-06-01..2026-06-30, 2026-07-04}".
+func (s *Set) String() string {
+	var strs []string
+	s.Ranges(func(first, last Date) bool {
+		strs = append(strs, Range{first, last}.String())
+		return true
+	})
+	return fmt.Sprintf("{%s}", strings.Join(strs, ", "))
+}
+
+// Copy returns a copy of a set that may be mutated without affecting the original.
+func (s *Set) Copy() *Set {
+	return &Set{s.set.Copy()}
+}
+
+// Insert adds d to the set. It returns a patch describing the change.
+func (s *Set) Insert(d Date) *Patch {
+	return s.InsertRange(d, d)
+}
+
+// InsertRange adds the dates from first to last inclusive to the set. It
+// returns a patch describing the dates that were not already in the set. It
+// panics if last is before first.
+func (s *Set) InsertRange(first, last Date) *Patch {
+	if last.Before(first) {
+		panic(fmt.Errorf("last date %s before first date %s", last, first))
+	}
+	return fromIntPatch(s.set.Insert(first.dayNumber(), last.dayNumber()+1))
+}
+
+// Add performs an in-place union of two sets. It returns a patch describing
+// the ranges that were actually added.
+func (s *Set) Add(b *Set) *Patch {
+	return fromIntPatch(s.set.Add(b.set))
+}
+
+// Sub performs an in-place subtraction of set b from set s. It returns a patch
+// describing the ranges that were actually removed.
+func (s *Set) Sub(b *Set) *Patch {
+	return fromIntPatch(s.set.Sub(b.set))
+}
+
+// Intersect performs an in-place intersection of sets s and b. It returns a
+// patch describing the ranges that were actually removed.
+func (s *Set) Intersect(b *Set) *Patch {
+	return fromIntPatch(s.set.Intersect(b.set))
+}
+
+// Empty reports whether the set has no members.
+func (s *Set) Empty() bool {
+	return s.set.Empty()
+}
+
+// Contains reports whether d is a member of the set.
+func (s *Set) Contains(d Date) bool {
+	return s.set.Contains(d.dayNumber())
+}
+
+// Len returns the number of dates in the set.
+func (s *Set) Len() int {
+	return int(s.set.Cardinality())
+}
+
+// Extent returns the first and last dates of the set. The third return value
+// is false if the set is empty.
+func (s *Set) Extent() (first, last Date, ok bool) {
+	if s.Empty() {
+		return Date{}, Date{}, false
+	}
+	lo, hi := s.set.Extent()
+	return fromDayNumber(lo), fromDayNumber(hi - 1), true
+}
+
+// RangeReceiver is a function used for iterating over the ranges of a set. It
+// takes the first and last (both inclusive) dates of a range and returns true
+// if the iteration should continue.
+type RangeReceiver func(first, last Date) bool
+
+// Ranges iterates over the ranges of consecutive dates within the set in
+// increasing order and calls f with the first and last date of each. If f
+// returns false, iteration ceases.
+func (s *Set) Ranges(f RangeReceiver) {
+	s.set.Intervals(func(lo, hi int64) bool {
+		return f(fromDayNumber(lo), fromDayNumber(hi-1))
+	})
+}
+
+// Days iterates over the dates of the set in increasing order and calls f with
+// each. If f returns false, iteration ceases.
+func (s *Set) Days(f func(Date) bool) {
+	s.set.Intervals(func(lo, hi int64) bool {
+		for n := lo; n < hi; n++ {
+			if !f(fromDayNumber(n)) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// OnWeekdays returns a new set containing the dates of s that fall on one of
+// the given days of the week. Values outside time.Sunday to time.Saturday
+// match no dates.
+func (s *Set) OnWeekdays(days ...time.Weekday) *Set {
+	var want [7]bool
+	for _, d := range days {
+		if d >= time.Sunday && d <= time.Saturday {
+			want[d] = true
+		}
+	}
+	result := Empty()
+	s.set.Intervals(func(lo, hi int64) bool {
+		// Only the first week of a range needs to be examined, since later
+		// dates repeat its weekdays.
+		for n := lo; n < hi && n < lo+7; n++ {
+			if !want[fromDayNumber(n).Weekday()] {
+				continue
+			}
+			for m := n; m < hi; m += 7 {
+				result.set.Insert(m, m+1)
+			}
+		}
+		return true
+	})
+	return result
+}
+
+// ToTimespanSet returns the instants covered by the dates of the set in loc.
+// Each date covers the instants from its Start in loc up to the Start of the
+// following date, so dates on which daylight saving time begins or ends may be
+// 23 or 25 hours long.
+func (s *Set) ToTimespanSet(loc *time.Location) *timespanset.Set {
+	result := timespanset.Empty()
+	s.Ranges(func(first, last Date) bool {
+		result.Insert(first.Start(loc), last.AddDays(1).Start(loc))
+		return true
+	})
+	return result
+}
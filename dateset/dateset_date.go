@@ -0,0 +1,104 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dateset
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/go-intervals/internal/civil"
+)
+
+const secondsPerDay = 24 * 60 * 60
+
+// Date is a civil date in the proleptic Gregorian calendar, independent of any
+// time zone.
+//
+// Dates outside the normal ranges are normalized in the same way as time.Date,
+// so that October 32 is November 1.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// DateOf returns the date on which t falls in t's location.
+func DateOf(t time.Time) Date {
+	y, m, d := t.Date()
+	return Date{y, m, d}
+}
+
+// ParseDate parses a date in "2006-01-02" format.
+func ParseDate(text string) (Date, error) {
+	t, err := time.Parse("2006-01-02", text)
+	if err != nil {
+		return Date{}, fmt.Errorf("dateset: invalid date %q: %w", text, err)
+	}
+	return DateOf(t), nil
+}
+
+// String returns the date in "2006-01-02" format.
+func (d Date) String() string {
+	d = d.normalize()
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, int(d.Month), d.Day)
+}
+
+// dayNumber returns the number of days between 1970-01-01 and d.
+func (d Date) dayNumber() int64 {
+	return floorDiv(time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, time.UTC).Unix(), secondsPerDay)
+}
+
+func fromDayNumber(n int64) Date {
+	return DateOf(time.Unix(n*secondsPerDay, 0).UTC())
+}
+
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
+
+func (d Date) normalize() Date {
+	return fromDayNumber(d.dayNumber())
+}
+
+// AddDays returns the date n days after d.
+func (d Date) AddDays(n int) Date {
+	return fromDayNumber(d.dayNumber() + int64(n))
+}
+
+// Weekday returns the day of the week of d.
+func (d Date) Weekday() time.Weekday {
+	// 1970-01-01 was a Thursday.
+	return time.Weekday((d.dayNumber()%7 + 7 + int64(time.Thursday)) % 7)
+}
+
+// Before reports whether d is before e.
+func (d Date) Before(e Date) bool {
+	return d.dayNumber() < e.dayNumber()
+}
+
+// After reports whether d is after e.
+func (d Date) After(e Date) bool {
+	return d.dayNumber() > e.dayNumber()
+}
+
+// Start returns the first instant of the date in loc. This is usually
+// midnight, but is later if a daylight saving transition skips midnight.
+func (d Date) Start(loc *time.Location) time.Time {
+	return civil.StartOfDay(d.Year, d.Month, d.Day, loc)
+}
@@ -0,0 +1,98 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dateset
+
+import (
+	"fmt"
+
+	"github.com/google/go-intervals/intset"
+)
+
+// Range is a range of consecutive dates. Both First and Last are inclusive.
+type Range struct {
+	First Date `json:"first"`
+	Last  Date `json:"last"`
+}
+
+// String returns a human readable version of the range, for example
+// "2026-06-01..2026-06-30".
+func (r Range) String() string {
+	if r.First == r.Last {
+		return r.First.String()
+	}
+	return fmt.Sprintf("%s..%s", r.First, r.Last)
+}
+
+// Patch is a set of edits that can be applied to a Set: Removed is subtracted
+// from the set, then Added is added to it. Patches may be serialized, for
+// example with encoding/json.
+type Patch struct {
+	Added   []Range `json:"added,omitempty"`
+	Removed []Range `json:"removed,omitempty"`
+}
+
+// NewPatch returns a patch that transforms oldSet into newSet.
+func NewPatch(oldSet, newSet *Set) *Patch {
+	return fromIntPatch(intset.NewPatch(oldSet.set, newSet.set))
+}
+
+// IsEmpty reports whether applying the patch has no effect on any set.
+func (p *Patch) IsEmpty() bool {
+	return len(p.Added) == 0 && len(p.Removed) == 0
+}
+
+// Apply destructively modifies s by applying the patch to it. It panics if
+// Last is before First in a range of the patch.
+func (p *Patch) Apply(s *Set) {
+	p.intPatch().Apply(s.set)
+}
+
+// Then returns a patch that is equivalent to applying p followed by next.
+func (p *Patch) Then(next *Patch) *Patch {
+	return fromIntPatch(p.intPatch().Then(next.intPatch()))
+}
+
+func (p *Patch) intPatch() *intset.Patch {
+	return &intset.Patch{
+		Added:   rangesToSpans(p.Added),
+		Removed: rangesToSpans(p.Removed),
+	}
+}
+
+func fromIntPatch(p *intset.Patch) *Patch {
+	return &Patch{
+		Added:   spansToRanges(p.Added),
+		Removed: spansToRanges(p.Removed),
+	}
+}
+
+func rangesToSpans(ranges []Range) []intset.Span {
+	var result []intset.Span
+	for _, r := range ranges {
+		if r.Last.Before(r.First) {
+			panic(fmt.Errorf("last date %s before first date %s", r.Last, r.First))
+		}
+		result = append(result, intset.Span{Lo: r.First.dayNumber(), Hi: r.Last.dayNumber() + 1})
+	}
+	return result
+}
+
+func spansToRanges(spans []intset.Span) []Range {
+	var result []Range
+	for _, s := range spans {
+		result = append(result, Range{fromDayNumber(s.Lo), fromDayNumber(s.Hi - 1)})
+	}
+	return result
+}
@@ -0,0 +1,161 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dateset
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func loadLocation(name string) *time.Location {
+	x, err := time.LoadLocation(name)
+	if err != nil {
+		panic(fmt.Errorf("timezone not available: %v", err))
+	}
+	return x
+}
+
+func date(text string) Date {
+	d, err := ParseDate(text)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func TestDate(t *testing.T) {
+	d := date("2026-02-28")
+	if got, want := d.AddDays(1), date("2026-03-01"); got != want {
+		t.Errorf("AddDays(1) = %s, want %s", got, want)
+	}
+	if got, want := date("1970-01-01").AddDays(-1), date("1969-12-31"); got != want {
+		t.Errorf("AddDays() = %s, want %s", got, want)
+	}
+	if got, want := (Date{2024, time.February, 30}).String(), "2024-03-01"; got != want {
+		t.Errorf("String() = %s, want %s", got, want)
+	}
+	for _, text := range []string{"1969-12-31", "1970-01-01", "2026-10-18", "1066-10-14"} {
+		d := date(text)
+		want := time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, time.UTC).Weekday()
+		if got := d.Weekday(); got != want {
+			t.Errorf("%s.Weekday() = %s, want %s", text, got, want)
+		}
+	}
+	if _, err := ParseDate("2026-13-01"); err == nil {
+		t.Errorf("ParseDate(2026-13-01) = nil error, want error")
+	}
+}
+
+func TestSet(t *testing.T) {
+	s := Empty()
+	s.InsertRange(date("2026-06-01"), date("2026-06-30"))
+	s.Insert(date("2026-07-04"))
+	holidays := Empty()
+	holidays.Insert(date("2026-06-19"))
+	s.Sub(holidays)
+
+	if got, want := s.String(), "{2026-06-01..2026-06-18, 2026-06-20..2026-06-30, 2026-07-04}"; got != want {
+		t.Errorf("String() = %s, want %s", got, want)
+	}
+	if got, want := s.Len(), 30; got != want {
+		t.Errorf("Len() = %d, want %d", got, want)
+	}
+	if !s.Contains(date("2026-06-18")) || s.Contains(date("2026-06-19")) {
+		t.Errorf("Contains gave unexpected results for %s", s)
+	}
+	if first, last, ok := s.Extent(); !ok || first != date("2026-06-01") || last != date("2026-07-04") {
+		t.Errorf("Extent() = %s, %s, %v", first, last, ok)
+	}
+
+	weekends := s.OnWeekdays(time.Saturday, time.Sunday)
+	if got, want := weekends.Len(), 9; got != want {
+		t.Errorf("OnWeekdays().Len() = %d, want %d: %s", got, want, weekends)
+	}
+	if got := s.OnWeekdays(-1, 7, 100).Len(); got != 0 {
+		t.Errorf("OnWeekdays(-1, 7, 100).Len() = %d, want 0", got)
+	}
+	var days []Date
+	weekends.Days(func(d Date) bool {
+		days = append(days, d)
+		return len(days) < 3
+	})
+	if want := []Date{date("2026-06-06"), date("2026-06-07"), date("2026-06-13")}; !reflect.DeepEqual(days, want) {
+		t.Errorf("Days() = %v, want %v", days, want)
+	}
+
+	workdays := s.Copy()
+	workdays.Sub(weekends)
+	if got, want := workdays.Len(), 21; got != want {
+		t.Errorf("workdays.Len() = %d, want %d", got, want)
+	}
+}
+
+func TestPatches(t *testing.T) {
+	s := Empty()
+	s.InsertRange(date("2026-06-01"), date("2026-06-10"))
+	if got, want := s.InsertRange(date("2026-06-05"), date("2026-06-12")), (&Patch{Added: []Range{{date("2026-06-11"), date("2026-06-12")}}}); !reflect.DeepEqual(got, want) {
+		t.Errorf("InsertRange() = %+v, want %+v", got, want)
+	}
+	holidays := Empty()
+	holidays.Insert(date("2026-06-04"))
+	if got, want := s.Sub(holidays), (&Patch{Removed: []Range{{date("2026-06-04"), date("2026-06-04")}}}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Sub() = %+v, want %+v", got, want)
+	}
+	june := Empty()
+	june.InsertRange(date("2026-06-02"), date("2026-06-30"))
+	if got, want := s.Intersect(june), (&Patch{Removed: []Range{{date("2026-06-01"), date("2026-06-01")}}}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Intersect() = %+v, want %+v", got, want)
+	}
+	if got, want := s.Add(holidays), (&Patch{Added: []Range{{date("2026-06-04"), date("2026-06-04")}}}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Add() = %+v, want %+v", got, want)
+	}
+
+	old := Empty()
+	old.Insert(date("2026-05-31"))
+	NewPatch(old, june).Apply(old)
+	if got, want := old.String(), june.String(); got != want {
+		t.Errorf("NewPatch(old, june).Apply(old) = %s, want %s", got, want)
+	}
+}
+
+func TestToTimespanSet(t *testing.T) {
+	pacific := loadLocation("PST8PDT")
+	s := Empty()
+	// Daylight saving time began on March 8 and ended on November 1, 2026.
+	s.Insert(date("2026-03-08"))
+	s.Insert(date("2026-11-01"))
+	s.Insert(date("2026-06-15"))
+
+	var hours []float64
+	s.ToTimespanSet(pacific).IntervalsBetween(time.Time{}, time.Date(3000, 1, 1, 0, 0, 0, 0, time.UTC), func(start, end time.Time) bool {
+		hours = append(hours, end.Sub(start).Hours())
+		return true
+	})
+	if want := []float64{23, 24, 25}; !reflect.DeepEqual(hours, want) {
+		t.Errorf("day lengths = %v, want %v", hours, want)
+	}
+
+	// In 2018 Sao Paulo skipped from midnight to 1am on November 4.
+	saoPaulo := loadLocation("America/Sao_Paulo")
+	start := date("2018-11-04").Start(saoPaulo)
+	if want := time.Date(2018, time.November, 4, 1, 0, 0, 0, saoPaulo); !start.Equal(want) {
+		t.Errorf("Start() = %s, want %s", start, want)
+	}
+	if got := date("2018-11-05").Start(saoPaulo).Sub(start); got != 23*time.Hour {
+		t.Errorf("length of 2018-11-04 = %s, want 23h", got)
+	}
+}
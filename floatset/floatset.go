@@ -0,0 +1,212 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package floatset is a finite set implementation for ranges of float64
+// values.
+//
+// Ranges include their lower bound and exclude their upper bound. NaN is never
+// a valid bound, while math.Inf(-1) and math.Inf(1) are. Because ranges exclude
+// their upper bound, +Inf itself is never a member of a set.
+//
+// A set may be created with an adjacency tolerance, in which case two ranges
+// separated by a gap no larger than the tolerance are coalesced. For example,
+// with a tolerance of 1e-12, [0, 0.1) and [0.1+1e-15, 1) become [0, 1). Gaps
+// that small are also absorbed when subtracting.
+//
+// DISCLAIMER: This library is not yet stable, so expect breaking changes.
+package floatset
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/google/go-intervals/intervalset"
+)
+
+// ErrNaN is the value Insert panics with when NaN is used as the bound of a
+// range.
+var ErrNaN = errors.New("floatset: NaN is not a valid bound")
+
+// Set is a finite set of float64 ranges. Functions are provided for iterating
+// over the ranges and performing set operations (intersection, union,
+// subtraction).
+//
+// This is a float64-specific implementation of intervalset.Set.
+type Set struct {
+	iset *intervalset.Set
+	eps  float64
+}
+
+// Empty returns a new, empty Set with no adjacency tolerance.
+func Empty() *Set {
+	return EmptyWithTolerance(0)
+}
+
+// EmptyWithTolerance returns a new, empty Set that coalesces ranges separated
+// by a gap no larger than epsilon. It panics if epsilon is negative or NaN.
+func EmptyWithTolerance(epsilon float64) *Set {
+	if !(epsilon >= 0) {
+		panic(fmt.Errorf("invalid tolerance %g", epsilon))
+	}
+	return &Set{
+		iset: intervalset.EmptyV1(func() intervalset.Interval { return &span{eps: epsilon} }),
+		eps:  epsilon,
+	}
+}
+
+// Tolerance returns the adjacency tolerance of the set.
+func (s *Set) Tolerance() float64 {
+	return s.eps
+}
+
+// String returns a human readable version of the set.
+func (s *Set) String() string {
+	return s.iset.String()
+}
+
+// Copy returns a copy of a set that may be mutated without affecting the original.
+func (s *Set) Copy() *Set {
+	return &Set{s.iset.Copy(), s.eps}
+}
+
+// checkRange returns an error if [lo, hi) is not a valid range.
+func checkRange(lo, hi float64) error {
+	if math.IsNaN(lo) || math.IsNaN(hi) {
+		return ErrNaN
+	}
+	if hi < lo {
+		return fmt.Errorf("floatset: hi %g less than lo %g", hi, lo)
+	}
+	return nil
+}
+
+// Insert adds the range [lo, hi) to the set. It returns a patch describing the
+// portion of the range that was not already in the set. It panics with ErrNaN
+// if either bound is NaN, and panics if hi < lo.
+func (s *Set) Insert(lo, hi float64) *Patch {
+	if err := checkRange(lo, hi); err != nil {
+		panic(err)
+	}
+	if lo == hi {
+		return &Patch{}
+	}
+	return fromIntervalPatch(s.iset.Add(intervalset.NewSet([]intervalset.Interval{&span{lo, hi, s.eps}})))
+}
+
+// withTolerance returns the intervals of b using the tolerance of s.
+func (s *Set) withTolerance(b *Set) intervalset.SetInput {
+	if b.eps == s.eps {
+		return b.iset
+	}
+	var intervals []intervalset.Interval
+	b.iset.Intervals(func(x intervalset.Interval) bool {
+		sp := spanOrPanic(x)
+		intervals = append(intervals, &span{sp.lo, sp.hi, s.eps})
+		return true
+	})
+	return intervalset.NewSet(intervals)
+}
+
+// Add performs an in-place union of two sets. The tolerance of s is used. It
+// returns a patch describing the ranges that were actually added.
+func (s *Set) Add(b *Set) *Patch {
+	return fromIntervalPatch(s.iset.Add(s.withTolerance(b)))
+}
+
+// Sub performs an in-place subtraction of set b from set s. The tolerance of s
+// is used. It returns a patch describing the ranges that were actually
+// removed.
+func (s *Set) Sub(b *Set) *Patch {
+	return fromIntervalPatch(s.iset.Sub(s.withTolerance(b)))
+}
+
+// Intersect performs an in-place intersection of sets s and b. It returns a
+// patch describing the ranges that were actually removed.
+func (s *Set) Intersect(b *Set) *Patch {
+	return fromIntervalPatch(s.iset.Intersect(s.withTolerance(b)))
+}
+
+// Extent returns the range [lo, hi) covering the entire set. Both values are
+// zero for an empty set.
+func (s *Set) Extent() (lo, hi float64) {
+	x := spanOrPanic(s.iset.Extent())
+	return x.lo, x.hi
+}
+
+// Empty reports whether the set has no members.
+func (s *Set) Empty() bool {
+	return s.iset.Extent().IsZero()
+}
+
+// Contains reports whether the range [lo, hi) is entirely contained within the
+// set. It returns false if either bound is NaN or if hi < lo.
+func (s *Set) Contains(lo, hi float64) bool {
+	if checkRange(lo, hi) != nil {
+		return false
+	}
+	if lo == hi {
+		return true
+	}
+	return s.iset.Contains(&span{lo, hi, s.eps})
+}
+
+// ContainsPoint reports whether x is a member of the set.
+func (s *Set) ContainsPoint(x float64) bool {
+	next := math.Nextafter(x, math.Inf(1))
+	if math.IsNaN(x) || next == x {
+		return false
+	}
+	return s.iset.Contains(&span{x, next, s.eps})
+}
+
+// Measure returns the total length of the ranges in the set. It is +Inf if the
+// set contains an unbounded range.
+func (s *Set) Measure() float64 {
+	total := 0.0
+	s.Intervals(func(lo, hi float64) bool {
+		total += hi - lo
+		return true
+	})
+	return total
+}
+
+// IntervalReceiver is a function used for iterating over a set of float
+// ranges. It takes the lower (inclusive) and upper (exclusive) bounds of a range
+// and returns true if the iteration should continue.
+type IntervalReceiver func(lo, hi float64) bool
+
+// IntervalsBetween iterates over the ranges within the set and calls f with the
+// lower (inclusive) and upper (exclusive) bound of each. If f returns false,
+// iteration ceases. Ranges are truncated to [lo, hi). Nothing is iterated over
+// if [lo, hi) is not a valid, non-empty range.
+func (s *Set) IntervalsBetween(lo, hi float64, f IntervalReceiver) {
+	if checkRange(lo, hi) != nil || lo == hi {
+		return
+	}
+	s.iset.IntervalsBetween(&span{lo, hi, s.eps}, func(x intervalset.Interval) bool {
+		sp := spanOrPanic(x)
+		return f(sp.lo, sp.hi)
+	})
+}
+
+// Intervals iterates over all the ranges within the set and calls f with the
+// lower (inclusive) and upper (exclusive) bound of each. If f returns false,
+// iteration ceases.
+func (s *Set) Intervals(f IntervalReceiver) {
+	s.iset.Intervals(func(x intervalset.Interval) bool {
+		sp := spanOrPanic(x)
+		return f(sp.lo, sp.hi)
+	})
+}
@@ -0,0 +1,106 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package floatset
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/google/go-intervals/intervalset"
+)
+
+// span is a private implementation of intervalset.Interval for [lo, hi).
+//
+// Two spans adjoin if the gap between them is no larger than eps.
+type span struct {
+	lo, hi float64
+	eps    float64
+}
+
+func (s *span) String() string {
+	return fmt.Sprintf("[%g, %g)", s.lo, s.hi)
+}
+
+func spanOrPanic(i intervalset.Interval) *span {
+	s, ok := i.(*span)
+	if !ok {
+		panic(fmt.Errorf("interval must be a float span: %v", i))
+	}
+	return s
+}
+
+func (s *span) zero() *span {
+	return &span{eps: s.eps}
+}
+
+func (s *span) intersect(b *span) *span {
+	result := &span{math.Max(s.lo, b.lo), math.Min(s.hi, b.hi), s.eps}
+	if result.lo < result.hi {
+		return result
+	}
+	return s.zero()
+}
+
+func (s *span) IsZero() bool {
+	return s.lo == 0 && s.hi == 0
+}
+
+func (s *span) Before(other intervalset.Interval) bool {
+	return s.hi <= spanOrPanic(other).lo
+}
+
+func (s *span) Intersect(other intervalset.Interval) intervalset.Interval {
+	return s.intersect(spanOrPanic(other))
+}
+
+func (s *span) Bisect(other intervalset.Interval) (intervalset.Interval, intervalset.Interval) {
+	b := spanOrPanic(other)
+	intersection := s.intersect(b)
+	if intersection.IsZero() {
+		if s.Before(b) {
+			return s, s.zero()
+		}
+		return s.zero(), s
+	}
+	maybeZero := func(lo, hi float64) *span {
+		if lo == hi {
+			return s.zero()
+		}
+		return &span{lo, hi, s.eps}
+	}
+	return maybeZero(s.lo, intersection.lo), maybeZero(intersection.hi, s.hi)
+}
+
+// adjoins reports whether b starts no more than eps after a ends.
+func adjoins(a, b *span, eps float64) bool {
+	gap := b.lo - a.hi
+	return gap == 0 || (gap > 0 && gap <= eps)
+}
+
+func (s *span) Adjoin(other intervalset.Interval) intervalset.Interval {
+	b := spanOrPanic(other)
+	if adjoins(s, b, s.eps) {
+		return &span{s.lo, b.hi, s.eps}
+	}
+	if adjoins(b, s, s.eps) {
+		return &span{b.lo, s.hi, s.eps}
+	}
+	return s.zero()
+}
+
+func (s *span) Encompass(other intervalset.Interval) intervalset.Interval {
+	b := spanOrPanic(other)
+	return &span{math.Min(s.lo, b.lo), math.Max(s.hi, b.hi), s.eps}
+}
@@ -0,0 +1,99 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package floatset
+
+import (
+	"fmt"
+
+	"github.com/google/go-intervals/intervalset"
+)
+
+// Span is a range of float64 values that includes Lo and excludes Hi.
+type Span struct {
+	Lo float64 `json:"lo"`
+	Hi float64 `json:"hi"`
+}
+
+// String returns a human readable version of the span.
+func (s Span) String() string {
+	return fmt.Sprintf("[%g, %g)", s.Lo, s.Hi)
+}
+
+// Patch is a set of edits that can be applied to a Set: Removed is subtracted
+// from the set, then Added is added to it. Patches may be serialized, for
+// example with encoding/json, unless they contain an infinite bound.
+type Patch struct {
+	Added   []Span `json:"added,omitempty"`
+	Removed []Span `json:"removed,omitempty"`
+}
+
+// NewPatch returns a patch that transforms oldSet into newSet. The ranges of
+// newSet are compared using the tolerance of oldSet.
+func NewPatch(oldSet, newSet *Set) *Patch {
+	return fromIntervalPatch(intervalset.NewPatch(oldSet.iset, oldSet.withTolerance(newSet)))
+}
+
+// IsEmpty reports whether applying the patch has no effect on any set.
+func (p *Patch) IsEmpty() bool {
+	return len(p.Added) == 0 && len(p.Removed) == 0
+}
+
+// Apply destructively modifies s by applying the patch to it, using the
+// tolerance of s. It panics if a span of the patch is invalid.
+func (p *Patch) Apply(s *Set) {
+	p.intervalPatch(s.eps).Apply(s.iset)
+}
+
+// Then returns a patch that is equivalent to applying p followed by next.
+func (p *Patch) Then(next *Patch) *Patch {
+	return fromIntervalPatch(p.intervalPatch(0).Then(next.intervalPatch(0)))
+}
+
+func (p *Patch) intervalPatch(eps float64) *intervalset.Patch {
+	return &intervalset.Patch{
+		Added:   spansToIntervals(p.Added, eps),
+		Removed: spansToIntervals(p.Removed, eps),
+	}
+}
+
+func fromIntervalPatch(p *intervalset.Patch) *Patch {
+	return &Patch{
+		Added:   intervalsToSpans(p.Added),
+		Removed: intervalsToSpans(p.Removed),
+	}
+}
+
+func spansToIntervals(spans []Span, eps float64) []intervalset.Interval {
+	var result []intervalset.Interval
+	for _, s := range spans {
+		if err := checkRange(s.Lo, s.Hi); err != nil {
+			panic(err)
+		}
+		if s.Lo == s.Hi {
+			continue
+		}
+		result = append(result, &span{s.Lo, s.Hi, eps})
+	}
+	return result
+}
+
+func intervalsToSpans(intervals []intervalset.Interval) []Span {
+	var result []Span
+	for _, x := range intervals {
+		sp := spanOrPanic(x)
+		result = append(result, Span{sp.lo, sp.hi})
+	}
+	return result
+}
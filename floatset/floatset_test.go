@@ -0,0 +1,217 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package floatset
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+var inf = math.Inf(1)
+
+type pair struct {
+	lo, hi float64
+}
+
+func pairs(s *Set) []pair {
+	result := []pair{}
+	s.Intervals(func(lo, hi float64) bool {
+		result = append(result, pair{lo, hi})
+		return true
+	})
+	return result
+}
+
+func setOf(t *testing.T, s *Set, ps ...pair) *Set {
+	t.Helper()
+	for _, p := range ps {
+		s.Insert(p.lo, p.hi)
+	}
+	return s
+}
+
+func TestInsertPanics(t *testing.T) {
+	s := Empty()
+	insert := func(lo, hi float64) (r interface{}) {
+		defer func() { r = recover() }()
+		s.Insert(lo, hi)
+		return nil
+	}
+	if r := insert(math.NaN(), 1); r != ErrNaN {
+		t.Errorf("Insert(NaN, 1) panicked with %v, want ErrNaN", r)
+	}
+	if r := insert(0, math.NaN()); r != ErrNaN {
+		t.Errorf("Insert(0, NaN) panicked with %v, want ErrNaN", r)
+	}
+	if r := insert(2, 1); r == nil {
+		t.Errorf("Insert(2, 1) did not panic")
+	}
+	if r := insert(inf, inf); r != nil || !s.Empty() {
+		t.Errorf("Insert(+Inf, +Inf) panicked with %v, set %v, want no-op", r, s)
+	}
+}
+
+func TestPatches(t *testing.T) {
+	s := setOf(t, EmptyWithTolerance(1e-12), pair{0, 1})
+	if got, want := s.Insert(0.5, 2), (&Patch{Added: []Span{{1, 2}}}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Insert(0.5, 2) = %+v, want %+v", got, want)
+	}
+	if got, want := s.Sub(setOf(t, Empty(), pair{-inf, 0.5})), (&Patch{Removed: []Span{{0, 0.5}}}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Sub() = %+v, want %+v", got, want)
+	}
+	if got, want := s.Intersect(setOf(t, Empty(), pair{0, 1.5})), (&Patch{Removed: []Span{{1.5, 2}}}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Intersect() = %+v, want %+v", got, want)
+	}
+	if got, want := s.Add(setOf(t, Empty(), pair{1.5 + 1e-15, 3})), (&Patch{Added: []Span{{1.5 + 1e-15, 3}}}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Add() across a tolerated gap = %+v, want %+v", got, want)
+	}
+
+	old, updated := setOf(t, Empty(), pair{0, 5}), setOf(t, Empty(), pair{3, inf})
+	NewPatch(old, updated).Apply(old)
+	if got, want := pairs(old), pairs(updated); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewPatch(old, updated).Apply(old) = %v, want %v", got, want)
+	}
+}
+
+func TestSetOperations(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		a, b    []pair
+		op      func(a, b *Set) *Patch
+		want    []pair
+		epsilon float64
+	}{
+		{
+			name: "add adjoining",
+			a:    []pair{{0, 1}},
+			b:    []pair{{1, 2}, {3, 4}},
+			op:   (*Set).Add,
+			want: []pair{{0, 2}, {3, 4}},
+		},
+		{
+			name: "add tiny gap without tolerance",
+			a:    []pair{{0, 0.1}},
+			b:    []pair{{0.1 + 1e-15, 1}},
+			op:   (*Set).Add,
+			want: []pair{{0, 0.1}, {0.1 + 1e-15, 1}},
+		},
+		{
+			name:    "add tiny gap with tolerance",
+			a:       []pair{{0, 0.1}},
+			b:       []pair{{0.1 + 1e-15, 1}},
+			op:      (*Set).Add,
+			want:    []pair{{0, 1}},
+			epsilon: 1e-12,
+		},
+		{
+			name:    "tolerance does not bridge larger gaps",
+			a:       []pair{{0, 0.1}},
+			b:       []pair{{0.2, 1}},
+			op:      (*Set).Add,
+			want:    []pair{{0, 0.1}, {0.2, 1}},
+			epsilon: 1e-12,
+		},
+		{
+			name: "infinite bounds",
+			a:    []pair{{-inf, 0}},
+			b:    []pair{{0, inf}},
+			op:   (*Set).Add,
+			want: []pair{{-inf, inf}},
+		},
+		{
+			name: "sub from unbounded",
+			a:    []pair{{-inf, inf}},
+			b:    []pair{{-1, 1}},
+			op:   (*Set).Sub,
+			want: []pair{{-inf, -1}, {1, inf}},
+		},
+		{
+			name: "intersect",
+			a:    []pair{{-inf, 0}, {1, 2}},
+			b:    []pair{{-1, 1.5}},
+			op:   (*Set).Intersect,
+			want: []pair{{-1, 0}, {1, 1.5}},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			a := setOf(t, EmptyWithTolerance(tt.epsilon), tt.a...)
+			b := setOf(t, Empty(), tt.b...)
+			tt.op(a, b)
+			if got := pairs(a); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueries(t *testing.T) {
+	s := setOf(t, Empty(), pair{-inf, -10}, pair{0, 1}, pair{2, inf})
+
+	if lo, hi := s.Extent(); lo != -inf || hi != inf {
+		t.Errorf("Extent() = [%g, %g), want [-Inf, +Inf)", lo, hi)
+	}
+	if got := s.Measure(); got != inf {
+		t.Errorf("Measure() = %g, want +Inf", got)
+	}
+	if got := setOf(t, Empty(), pair{0, 0.5}, pair{1, 3}).Measure(); got != 2.5 {
+		t.Errorf("Measure() = %g, want 2.5", got)
+	}
+
+	for _, tt := range []struct {
+		x    float64
+		want bool
+	}{
+		{-inf, true},
+		{-10, false},
+		{0, true},
+		{math.Nextafter(1, 0), true},
+		{1, false},
+		{1e300, true},
+		{inf, false},
+		{math.NaN(), false},
+	} {
+		if got := s.ContainsPoint(tt.x); got != tt.want {
+			t.Errorf("ContainsPoint(%g) = %v, want %v", tt.x, got, tt.want)
+		}
+	}
+
+	if !s.Contains(0.25, 0.75) || s.Contains(0.5, 2.5) || s.Contains(math.NaN(), 1) {
+		t.Errorf("Contains gave unexpected results for %v", s)
+	}
+
+	var got []pair
+	s.IntervalsBetween(-20, 5, func(lo, hi float64) bool {
+		got = append(got, pair{lo, hi})
+		return true
+	})
+	want := []pair{{-20, -10}, {0, 1}, {2, 5}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("IntervalsBetween(-20, 5) = %v, want %v", got, want)
+	}
+}
+
+func TestEmptyWithTolerancePanics(t *testing.T) {
+	for _, eps := range []float64{-1, math.NaN()} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("EmptyWithTolerance(%g) did not panic", eps)
+				}
+			}()
+			EmptyWithTolerance(eps)
+		}()
+	}
+}
@@ -0,0 +1,302 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package freeslot finds time slots in which the attendees of a meeting are
+// free, given the busy time of each attendee.
+//
+// The busy ranges of all attendees are walked once, in order of time, rather
+// than subtracted from one another as sets.
+//
+// DISCLAIMER: This library is not yet stable, so expect breaking changes.
+package freeslot
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/go-intervals/timespanset"
+)
+
+// Options controls the slots returned by Find.
+type Options struct {
+	// Start and End bound the search: every slot lies within [Start, End).
+	Start, End time.Time
+
+	// Duration is the length of each slot. It must be positive.
+	Duration time.Duration
+
+	// Alignment, if positive, restricts slot starts to multiples of Alignment
+	// since the zero time, as computed by time.Time.Truncate. Otherwise slots
+	// start at Start and wherever the number of free attendees or the
+	// preferred hours change, and then every Duration until the next change,
+	// so a free window eight times as long as Duration yields eight
+	// back-to-back slots.
+	Alignment time.Duration
+
+	// Quorum is the number of attendees who must be free for a slot to be
+	// returned. Zero means that all attendees must be free.
+	Quorum int
+
+	// Buffer is extra busy time added before and after each busy range, for
+	// example to allow travel between meetings.
+	Buffer time.Duration
+
+	// Preferred, if not nil, holds the preferred hours for the meeting. Slots
+	// that overlap more of the preferred hours rank higher.
+	Preferred timespanset.Source
+
+	// MaxResults, if positive, limits the number of slots returned.
+	MaxResults int
+}
+
+// Slot is a candidate time for a meeting.
+type Slot struct {
+	// Start and End are the bounds of the slot.
+	Start, End time.Time
+
+	// Free is the number of attendees who are free for the whole slot.
+	Free int
+
+	// Preferred is the amount of the slot within the preferred hours.
+	Preferred time.Duration
+}
+
+// String returns a human readable version of the slot.
+func (s Slot) String() string {
+	return fmt.Sprintf("[%s, %s) free=%d preferred=%s", s.Start.Format(time.RFC3339), s.End.Format(time.RFC3339), s.Free, s.Preferred)
+}
+
+// span is a busy range of an attendee.
+type span struct {
+	start, end time.Time
+}
+
+// event is the start or end of a busy or preferred range. pref is the change
+// in the number of preferred ranges at the instant.
+type event struct {
+	at   time.Time
+	pref int
+}
+
+// segment is a range of time between consecutive starts or ends of busy or
+// preferred ranges.
+type segment struct {
+	start, end time.Time
+	preferred  bool
+	// prefBefore is the amount of preferred time in earlier segments.
+	prefBefore time.Duration
+}
+
+// Find returns the slots within opts.Start and opts.End for which at least
+// opts.Quorum of the attendees, whose busy times are given by busy, are free.
+//
+// Slots are ranked by the number of free attendees, then by the amount of
+// preferred time they contain, then by start time. Slots may overlap one
+// another.
+//
+// Find panics if opts.Duration or opts.Quorum is invalid or opts.Buffer is
+// negative.
+func Find(busy []*timespanset.Set, opts Options) []Slot {
+	if opts.Duration <= 0 {
+		panic(fmt.Errorf("slot duration %s is not positive", opts.Duration))
+	}
+	if opts.Buffer < 0 {
+		panic(fmt.Errorf("buffer %s is negative", opts.Buffer))
+	}
+	quorum := opts.Quorum
+	if quorum == 0 {
+		quorum = len(busy)
+	}
+	if quorum < 0 || quorum > len(busy) {
+		panic(fmt.Errorf("quorum %d is not between 0 and the number of attendees, %d", opts.Quorum, len(busy)))
+	}
+	if opts.End.Sub(opts.Start) < opts.Duration {
+		return nil
+	}
+
+	ranges := busyRanges(busy, opts)
+	segs := sweep(ranges, opts)
+	counter := &freeCounter{ranges, make([]int, len(ranges))}
+	var result []Slot
+	// consider is called with increasing start times.
+	consider := func(start time.Time) {
+		if free := counter.free(start, start.Add(opts.Duration)); free >= quorum {
+			result = append(result, Slot{
+				Start:     start,
+				End:       start.Add(opts.Duration),
+				Free:      free,
+				Preferred: preferredBefore(segs, start.Add(opts.Duration)) - preferredBefore(segs, start),
+			})
+		}
+	}
+	last := opts.End.Add(-opts.Duration)
+	if opts.Alignment > 0 {
+		start := opts.Start.Truncate(opts.Alignment)
+		if start.Before(opts.Start) {
+			start = start.Add(opts.Alignment)
+		}
+		for ; !start.After(last); start = start.Add(opts.Alignment) {
+			consider(start)
+		}
+	} else {
+		for _, seg := range segs {
+			for start := seg.start; start.Before(seg.end) && !start.After(last); start = start.Add(opts.Duration) {
+				consider(start)
+			}
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Free != b.Free {
+			return a.Free > b.Free
+		}
+		if a.Preferred != b.Preferred {
+			return a.Preferred > b.Preferred
+		}
+		return a.Start.Before(b.Start)
+	})
+	if opts.MaxResults > 0 && len(result) > opts.MaxResults {
+		result = result[:opts.MaxResults]
+	}
+	return result
+}
+
+// busyRanges returns the busy ranges of each attendee within
+// [opts.Start, opts.End), extended by opts.Buffer, in increasing order.
+func busyRanges(busy []*timespanset.Set, opts Options) [][]span {
+	result := make([][]span, len(busy))
+	clip := func(start, end time.Time) (time.Time, time.Time, bool) {
+		if start.Before(opts.Start) {
+			start = opts.Start
+		}
+		if end.After(opts.End) {
+			end = opts.End
+		}
+		return start, end, start.Before(end)
+	}
+	for k, s := range busy {
+		// Buffers may make consecutive busy ranges of one attendee overlap,
+		// so they are merged.
+		var curStart, curEnd time.Time
+		flush := func() {
+			if start, end, ok := clip(curStart, curEnd); ok {
+				result[k] = append(result[k], span{start, end})
+			}
+		}
+		first := true
+		s.IntervalsBetween(opts.Start.Add(-opts.Buffer), opts.End.Add(opts.Buffer), func(start, end time.Time) bool {
+			start, end = start.Add(-opts.Buffer), end.Add(opts.Buffer)
+			switch {
+			case first:
+				curStart, curEnd, first = start, end, false
+			case start.After(curEnd):
+				flush()
+				curStart, curEnd = start, end
+			case end.After(curEnd):
+				curEnd = end
+			}
+			return true
+		})
+		if !first {
+			flush()
+		}
+	}
+	return result
+}
+
+// freeCounter counts the attendees who are free for a slot. Slots must be
+// passed in increasing order of start time.
+type freeCounter struct {
+	ranges [][]span
+	// next holds, for each attendee, the index of the first busy range that
+	// does not end before the start of the last slot.
+	next []int
+}
+
+// free returns the number of attendees with no busy time in [start, end).
+func (c *freeCounter) free(start, end time.Time) int {
+	n := 0
+	for k, rs := range c.ranges {
+		i := c.next[k]
+		for i < len(rs) && !rs[i].end.After(start) {
+			i++
+		}
+		c.next[k] = i
+		if i == len(rs) || !rs[i].start.Before(end) {
+			n++
+		}
+	}
+	return n
+}
+
+// sweep divides [opts.Start, opts.End) at the start and end of every busy and
+// preferred range.
+func sweep(ranges [][]span, opts Options) []segment {
+	var events []event
+	for _, rs := range ranges {
+		for _, r := range rs {
+			events = append(events, event{at: r.start}, event{at: r.end})
+		}
+	}
+	if opts.Preferred != nil {
+		opts.Preferred.IntervalsBetween(opts.Start, opts.End, func(start, end time.Time) bool {
+			events = append(events, event{at: start, pref: 1}, event{at: end, pref: -1})
+			return true
+		})
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].at.Before(events[j].at) })
+
+	var segs []segment
+	at, prefCount := opts.Start, 0
+	var prefBefore time.Duration
+	emit := func(end time.Time) {
+		if !at.Before(end) {
+			return
+		}
+		seg := segment{at, end, prefCount > 0, prefBefore}
+		if seg.preferred {
+			prefBefore += end.Sub(at)
+		}
+		segs = append(segs, seg)
+		at = end
+	}
+	for _, e := range events {
+		emit(e.at)
+		prefCount += e.pref
+	}
+	emit(opts.End)
+	return segs
+}
+
+// preferredBefore returns the amount of preferred time before t.
+func preferredBefore(segs []segment, t time.Time) time.Duration {
+	i := sort.Search(len(segs), func(i int) bool { return segs[i].end.After(t) })
+	if i == len(segs) {
+		if i == 0 {
+			return 0
+		}
+		last := segs[i-1]
+		if last.preferred {
+			return last.prefBefore + last.end.Sub(last.start)
+		}
+		return last.prefBefore
+	}
+	seg := segs[i]
+	if seg.preferred && t.After(seg.start) {
+		return seg.prefBefore + t.Sub(seg.start)
+	}
+	return seg.prefBefore
+}
@@ -0,0 +1,145 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package freeslot
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-intervals/timespanset"
+)
+
+func at(hour, minute int) time.Time {
+	return time.Date(2026, time.June, 1, hour, minute, 0, 0, time.UTC)
+}
+
+func busyAt(hours ...[4]int) *timespanset.Set {
+	s := timespanset.Empty()
+	for _, h := range hours {
+		s.Insert(at(h[0], h[1]), at(h[2], h[3]))
+	}
+	return s
+}
+
+type slot struct {
+	start string
+	free  int
+	pref  time.Duration
+}
+
+func slots(ss []Slot) []slot {
+	var result []slot
+	for _, s := range ss {
+		result = append(result, slot{s.Start.Format("15:04"), s.Free, s.Preferred})
+	}
+	return result
+}
+
+func team() []*timespanset.Set {
+	return []*timespanset.Set{
+		busyAt([4]int{9, 0, 10, 0}, [4]int{13, 0, 14, 0}),
+		busyAt([4]int{9, 30, 11, 0}),
+		busyAt([4]int{10, 0, 12, 0}),
+	}
+}
+
+func TestFind(t *testing.T) {
+	afternoon := busyAt([4]int{15, 0, 17, 0})
+	for _, tt := range []struct {
+		name string
+		busy []*timespanset.Set
+		opts Options
+		want []slot
+	}{
+		{
+			name: "everyone, aligned",
+			busy: team(),
+			opts: Options{Start: at(9, 0), End: at(17, 0), Duration: time.Hour, Alignment: 30 * time.Minute},
+			want: []slot{{"12:00", 3, 0}, {"14:00", 3, 0}, {"14:30", 3, 0}, {"15:00", 3, 0}, {"15:30", 3, 0}, {"16:00", 3, 0}},
+		},
+		{
+			name: "preferred hours",
+			busy: team(),
+			opts: Options{Start: at(9, 0), End: at(17, 0), Duration: time.Hour, Alignment: 30 * time.Minute, Preferred: afternoon, MaxResults: 4},
+			want: []slot{{"15:00", 3, time.Hour}, {"15:30", 3, time.Hour}, {"16:00", 3, time.Hour}, {"14:30", 3, 30 * time.Minute}},
+		},
+		{
+			name: "quorum",
+			busy: team(),
+			opts: Options{Start: at(9, 0), End: at(14, 0), Duration: time.Hour, Alignment: 30 * time.Minute, Quorum: 2},
+			want: []slot{{"12:00", 3, 0}, {"11:00", 2, 0}, {"11:30", 2, 0}, {"12:30", 2, 0}, {"13:00", 2, 0}},
+		},
+		{
+			name: "buffers",
+			busy: team(),
+			opts: Options{Start: at(9, 0), End: at(16, 0), Duration: time.Hour, Buffer: 15 * time.Minute},
+			want: []slot{{"14:15", 3, 0}},
+		},
+		{
+			name: "buffers of one attendee do not double count",
+			busy: []*timespanset.Set{busyAt([4]int{9, 0, 10, 0}, [4]int{10, 20, 11, 0}), timespanset.Empty()},
+			opts: Options{Start: at(9, 0), End: at(11, 15), Duration: time.Hour, Buffer: 15 * time.Minute, Quorum: 1},
+			want: []slot{{"09:00", 1, 0}, {"10:00", 1, 0}},
+		},
+		{
+			// Each attendee is free for half of the hour, but neither for all
+			// of it.
+			name: "attendees busy at different times",
+			busy: []*timespanset.Set{busyAt([4]int{9, 0, 9, 30}), busyAt([4]int{9, 30, 10, 0})},
+			opts: Options{Start: at(9, 0), End: at(10, 0), Duration: time.Hour, Quorum: 1},
+			want: nil,
+		},
+		{
+			name: "attendees busy at different times, longer window",
+			busy: []*timespanset.Set{busyAt([4]int{9, 0, 9, 30}), busyAt([4]int{9, 30, 10, 0})},
+			opts: Options{Start: at(9, 0), End: at(10, 30), Duration: time.Hour, Alignment: 30 * time.Minute, Quorum: 1},
+			want: []slot{{"09:30", 1, 0}},
+		},
+		{
+			name: "unaligned slots step by the duration",
+			busy: []*timespanset.Set{busyAt([4]int{9, 0, 9, 30})},
+			opts: Options{Start: at(9, 0), End: at(13, 0), Duration: time.Hour},
+			want: []slot{{"09:30", 1, 0}, {"10:30", 1, 0}, {"11:30", 1, 0}},
+		},
+		{
+			name: "window shorter than the duration",
+			busy: team(),
+			opts: Options{Start: at(9, 0), End: at(9, 30), Duration: time.Hour},
+			want: nil,
+		},
+	} {
+		if got := slots(Find(tt.busy, tt.opts)); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: Find() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFindPanics(t *testing.T) {
+	for name, opts := range map[string]Options{
+		"zero duration":   {Start: at(9, 0), End: at(17, 0)},
+		"negative buffer": {Start: at(9, 0), End: at(17, 0), Duration: time.Hour, Buffer: -time.Minute},
+		"quorum too big":  {Start: at(9, 0), End: at(17, 0), Duration: time.Hour, Quorum: 4},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: Find() did not panic", name)
+				}
+			}()
+			Find(team(), opts)
+		}()
+	}
+}
@@ -1,7 +1,9 @@
 module github.com/google/go-intervals
 
-// 1.12 was not chosen for any particular reason. This library was written in
-// 2017 and should work with fairly old Go releases.
-go 1.12
+// 1.19 is required by the recurring package, which uses time.Time.ZoneBounds,
+// and 1.18 by the ipset package, which uses net/netip. This library was
+// originally written in 2017 and should otherwise work with fairly old Go
+// releases.
+go 1.19
 
 require github.com/google/go-cmp v0.5.9
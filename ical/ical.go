@@ -0,0 +1,348 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ical expands the recurring events of RFC 5545 (iCalendar) into time
+// ranges.
+//
+// An Event is a timespanset.Source, so its occurrences are generated lazily
+// for the time range being queried, and events that recur forever can be
+// used without materializing them.
+//
+// DISCLAIMER: This library is not yet stable, so expect breaking changes.
+package ical
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-intervals/timespanset"
+)
+
+// Event is a possibly recurring event, described by the DTSTART, DTEND,
+// DURATION, RRULE, RDATE and EXDATE properties of a VEVENT.
+type Event struct {
+	// Start is the start of the first occurrence. Recurrence rules are
+	// expanded in its location.
+	Start time.Time
+
+	// Days and Duration give the length of each occurrence. Days are
+	// calendar days in the location of Start, so that an occurrence spanning
+	// a daylight saving transition keeps its wall-clock end time.
+	Days     int
+	Duration time.Duration
+
+	// Rules are the recurrence rules of the event. The occurrences of the
+	// event are the union of Start, the occurrences of each rule and RDates,
+	// except for those in ExDates.
+	Rules   []*Rule
+	RDates  []time.Time
+	ExDates []time.Time
+}
+
+var _ timespanset.Source = (*Event)(nil)
+
+// ParseEvent parses the properties of a VEVENT, one per line. Lines folded as
+// described by RFC 5545 are unfolded, and properties other than DTSTART,
+// DTEND, DURATION, RRULE, RDATE and EXDATE are ignored.
+//
+// A TZID parameter must name a location known to time.LoadLocation; VTIMEZONE
+// components are not interpreted. Floating times are interpreted in loc.
+func ParseEvent(text string, loc *time.Location) (*Event, error) {
+	e := &Event{}
+	var end time.Time
+	var hasDuration, startIsDate bool
+	var rules []string
+	for _, line := range unfold(text) {
+		name, params, value, ok := splitLine(line)
+		if !ok {
+			continue
+		}
+		lineLoc, err := paramLocation(params, loc)
+		if err != nil {
+			return nil, err
+		}
+		switch name {
+		case "DTSTART":
+			if e.Start, startIsDate, err = parseDateTime(value, lineLoc); err != nil {
+				return nil, err
+			}
+		case "DTEND":
+			if end, _, err = parseDateTime(value, lineLoc); err != nil {
+				return nil, err
+			}
+		case "DURATION":
+			if e.Days, e.Duration, err = parseDuration(value); err != nil {
+				return nil, err
+			}
+			hasDuration = true
+		case "RRULE":
+			rules = append(rules, value)
+		case "RDATE", "EXDATE":
+			for _, v := range strings.Split(value, ",") {
+				if strings.Contains(v, "/") {
+					return nil, fmt.Errorf("ical: %s periods are not supported: %q", name, v)
+				}
+				t, _, err := parseDateTime(v, lineLoc)
+				if err != nil {
+					return nil, err
+				}
+				if name == "RDATE" {
+					e.RDates = append(e.RDates, t)
+				} else {
+					e.ExDates = append(e.ExDates, t)
+				}
+			}
+		}
+	}
+	if e.Start.IsZero() {
+		return nil, fmt.Errorf("ical: event has no DTSTART")
+	}
+	for _, text := range rules {
+		r, err := ParseRule(text, e.Start.Location())
+		if err != nil {
+			return nil, err
+		}
+		e.Rules = append(e.Rules, r)
+	}
+	switch {
+	case !end.IsZero() && hasDuration:
+		return nil, fmt.Errorf("ical: event has both DTEND and DURATION")
+	case !end.IsZero() && startIsDate:
+		e.Days = daysBetween(e.Start, end)
+	case !end.IsZero():
+		e.Duration = end.Sub(e.Start)
+	case !hasDuration && startIsDate:
+		e.Days = 1
+	}
+	if e.Days < 0 || e.Duration < 0 {
+		return nil, fmt.Errorf("ical: event ends before it starts")
+	}
+	return e, nil
+}
+
+// unfold returns the content lines of text, joining lines folded as described
+// by RFC 5545.
+func unfold(text string) []string {
+	text = strings.NewReplacer("\r\n ", "", "\r\n\t", "", "\n ", "", "\n\t", "").Replace(text)
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, "\r")
+	}
+	return lines
+}
+
+// splitLine splits a content line into its upper-cased property name, its
+// parameters and its value. The last return value is false if the line has no
+// value.
+func splitLine(line string) (name string, params []string, value string, ok bool) {
+	nameAndParams, value, ok := strings.Cut(line, ":")
+	if !ok {
+		return "", nil, "", false
+	}
+	params = strings.Split(nameAndParams, ";")
+	return strings.ToUpper(params[0]), params[1:], value, true
+}
+
+// paramLocation returns the location named by a TZID parameter, or loc if
+// there is none.
+func paramLocation(params []string, loc *time.Location) (*time.Location, error) {
+	for _, p := range params {
+		name, value, _ := strings.Cut(p, "=")
+		if strings.EqualFold(name, "TZID") {
+			l, err := time.LoadLocation(strings.Trim(value, `"`))
+			if err != nil {
+				return nil, fmt.Errorf("ical: unknown TZID: %v", err)
+			}
+			return l, nil
+		}
+	}
+	return loc, nil
+}
+
+// parseDateTime parses a DATE or DATE-TIME value. Times ending in "Z" are in
+// UTC and others are in loc. The second return value is true for a DATE.
+func parseDateTime(value string, loc *time.Location) (time.Time, bool, error) {
+	var t time.Time
+	var err error
+	switch {
+	case len(value) == 8:
+		t, err = time.ParseInLocation("20060102", value, loc)
+		return t, true, wrapDateTimeError(value, err)
+	case strings.HasSuffix(value, "Z"):
+		t, err = time.Parse("20060102T150405Z", value)
+	default:
+		t, err = time.ParseInLocation("20060102T150405", value, loc)
+	}
+	return t, false, wrapDateTimeError(value, err)
+}
+
+func wrapDateTimeError(value string, err error) error {
+	if err != nil {
+		return fmt.Errorf("ical: invalid date or time %q", value)
+	}
+	return nil
+}
+
+// parseDuration parses a DURATION value such as "PT1H30M", "P1D" or "P2W". The
+// weeks and days are returned as a number of days.
+func parseDuration(value string) (days int, d time.Duration, err error) {
+	invalid := fmt.Errorf("ical: invalid duration %q", value)
+	s := strings.TrimPrefix(value, "+")
+	if !strings.HasPrefix(s, "P") || len(s) < 3 {
+		return 0, 0, invalid
+	}
+	s = s[1:]
+	inTime := false
+	for s != "" {
+		if s[0] == 'T' {
+			inTime = true
+			s = s[1:]
+			continue
+		}
+		i := strings.IndexFunc(s, func(r rune) bool { return r < '0' || r > '9' })
+		if i <= 0 {
+			return 0, 0, invalid
+		}
+		n, err := strconv.Atoi(s[:i])
+		if err != nil {
+			return 0, 0, invalid
+		}
+		switch unit := s[i]; {
+		case unit == 'W' && !inTime:
+			days += 7 * n
+		case unit == 'D' && !inTime:
+			days += n
+		case unit == 'H' && inTime:
+			d += time.Duration(n) * time.Hour
+		case unit == 'M' && inTime:
+			d += time.Duration(n) * time.Minute
+		case unit == 'S' && inTime:
+			d += time.Duration(n) * time.Second
+		default:
+			return 0, 0, invalid
+		}
+		s = s[i+1:]
+	}
+	return days, d, nil
+}
+
+// end returns the end of the occurrence starting at start.
+func (e *Event) end(start time.Time) time.Time {
+	return start.AddDate(0, 0, e.Days).Add(e.Duration)
+}
+
+// maxLength returns an upper bound on the length of an occurrence.
+func (e *Event) maxLength() time.Duration {
+	return time.Duration(e.Days)*25*time.Hour + e.Duration
+}
+
+// Occurrences calls f with the start of each occurrence of the event that
+// starts before end and ends after start, in increasing order. If f returns
+// false, iteration ceases.
+func (e *Event) Occurrences(start, end time.Time, f func(time.Time) bool) {
+	skipTo := start.Add(-e.maxLength())
+	iterators := make([]*ruleIterator, len(e.Rules))
+	heads := make([]time.Time, len(e.Rules))
+	ok := make([]bool, len(e.Rules))
+	for i, r := range e.Rules {
+		iterators[i] = newRuleIterator(r, e.Start)
+		iterators[i].skipTo(skipTo)
+		heads[i], ok[i] = iterators[i].next(end)
+	}
+	extra := append([]time.Time{e.Start}, e.RDates...)
+	sort.Slice(extra, func(i, j int) bool { return extra[i].Before(extra[j]) })
+
+	var last time.Time
+	for {
+		// Find the earliest pending occurrence. from is the index of the rule
+		// that generated it, or -1 if it is Start or an RDATE.
+		var next time.Time
+		found, from := false, -1
+		if len(extra) > 0 {
+			next, found = extra[0], true
+		}
+		for i := range iterators {
+			if ok[i] && (!found || heads[i].Before(next)) {
+				next, found, from = heads[i], true, i
+			}
+		}
+		if !found || !next.Before(end) {
+			return
+		}
+		if from < 0 {
+			extra = extra[1:]
+		} else {
+			heads[from], ok[from] = iterators[from].next(end)
+		}
+		if next.Equal(last) || e.excluded(next) || !e.end(next).After(start) {
+			continue
+		}
+		last = next
+		if !f(next) {
+			return
+		}
+	}
+}
+
+func (e *Event) excluded(t time.Time) bool {
+	for _, x := range e.ExDates {
+		if x.Equal(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// IntervalsBetween calls f with the start (inclusive) and end (exclusive) of
+// each range of time covered by occurrences of the event, truncated to
+// [start, end). Overlapping and adjoining occurrences are combined. If f
+// returns false, iteration ceases.
+func (e *Event) IntervalsBetween(start, end time.Time, f timespanset.IntervalReceiver) {
+	var curStart, curEnd time.Time
+	stopped := false
+	e.Occurrences(start, end, func(s time.Time) bool {
+		x := e.end(s)
+		if !x.After(s) {
+			return true
+		}
+		if s.Before(start) {
+			s = start
+		}
+		if !curEnd.IsZero() && !s.After(curEnd) {
+			if x.After(curEnd) {
+				curEnd = x
+			}
+			return true
+		}
+		if !curEnd.IsZero() && !f(curStart, minTime(curEnd, end)) {
+			stopped = true
+			return false
+		}
+		curStart, curEnd = s, x
+		return true
+	})
+	if !stopped && !curEnd.IsZero() {
+		f(curStart, minTime(curEnd, end))
+	}
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,268 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/go-intervals/timespanset"
+)
+
+// FBType is the FBTYPE parameter of a FREEBUSY property.
+type FBType string
+
+// Free/busy types defined by RFC 5545. Other values may be used for
+// experimental types.
+const (
+	Free            FBType = "FREE"
+	Busy            FBType = "BUSY"
+	BusyUnavailable FBType = "BUSY-UNAVAILABLE"
+	BusyTentative   FBType = "BUSY-TENTATIVE"
+)
+
+// PeriodForm selects how Encode writes the periods of a FREEBUSY property.
+type PeriodForm int
+
+const (
+	// PeriodWithEnd writes periods as "start/end".
+	PeriodWithEnd PeriodForm = iota
+
+	// PeriodWithDuration writes periods as "start/duration".
+	PeriodWithDuration
+)
+
+// FreeBusy is a VFREEBUSY component: free/busy time within [Start, End),
+// grouped by free/busy type.
+type FreeBusy struct {
+	// UID and Stamp are written as the UID and DTSTAMP properties if they are
+	// set.
+	UID   string
+	Stamp time.Time
+
+	Start, End time.Time
+	Periods    map[FBType]*timespanset.Set
+}
+
+// Encode writes fb as a VFREEBUSY component with CRLF line endings, folding
+// lines longer than 75 octets. Only the ranges of each set within [Start, End)
+// are written, using Set.IntervalsBetween, so a window of a larger set may be
+// exported. Use timespanset.Between to export a timespanset.Source such as an
+// Event.
+//
+// All times are written in UTC with a precision of one second. The start of a
+// range is rounded down and its end rounded up, so that busy time is never
+// understated.
+func (fb *FreeBusy) Encode(w io.Writer, form PeriodForm) error {
+	if !fb.Start.Before(fb.End) {
+		return fmt.Errorf("ical: free/busy end %s is not after start %s", fb.End, fb.Start)
+	}
+	bw := bufio.NewWriter(w)
+	writeLine := func(line string) {
+		bw.WriteString(fold(line))
+	}
+	writeLine("BEGIN:VFREEBUSY")
+	if fb.UID != "" {
+		writeLine("UID:" + fb.UID)
+	}
+	if !fb.Stamp.IsZero() {
+		writeLine("DTSTAMP:" + formatUTC(fb.Stamp.Truncate(time.Second)))
+	}
+	writeLine("DTSTART:" + formatUTC(floorSecond(fb.Start)))
+	writeLine("DTEND:" + formatUTC(ceilSecond(fb.End)))
+
+	var types []string
+	for t := range fb.Periods {
+		types = append(types, string(t))
+	}
+	sort.Strings(types)
+	for _, t := range types {
+		var periods []string
+		fb.Periods[FBType(t)].IntervalsBetween(fb.Start, fb.End, func(start, end time.Time) bool {
+			start, end = floorSecond(start), ceilSecond(end)
+			if form == PeriodWithDuration {
+				periods = append(periods, formatUTC(start)+"/"+formatDuration(end.Sub(start)))
+			} else {
+				periods = append(periods, formatUTC(start)+"/"+formatUTC(end))
+			}
+			return true
+		})
+		if len(periods) > 0 {
+			writeLine(fmt.Sprintf("FREEBUSY;FBTYPE=%s:%s", t, strings.Join(periods, ",")))
+		}
+	}
+	writeLine("END:VFREEBUSY")
+	return bw.Flush()
+}
+
+// ParseFreeBusy parses the DTSTART, DTEND and FREEBUSY properties of a
+// VFREEBUSY component. FREEBUSY periods without an FBTYPE parameter are
+// busy. If DTSTART or DTEND is missing, the extent of the periods is used.
+// Times without a "Z" suffix or TZID parameter are interpreted in UTC.
+func ParseFreeBusy(text string) (*FreeBusy, error) {
+	fb := &FreeBusy{Periods: map[FBType]*timespanset.Set{}}
+	for _, line := range unfold(text) {
+		name, params, value, ok := splitLine(line)
+		if !ok {
+			continue
+		}
+		loc, err := paramLocation(params, time.UTC)
+		if err != nil {
+			return nil, err
+		}
+		switch name {
+		case "UID":
+			fb.UID = value
+		case "DTSTAMP":
+			fb.Stamp, _, err = parseDateTime(value, loc)
+		case "DTSTART":
+			fb.Start, _, err = parseDateTime(value, loc)
+		case "DTEND":
+			fb.End, _, err = parseDateTime(value, loc)
+		case "FREEBUSY":
+			fbType := Busy
+			for _, p := range params {
+				if n, v, _ := strings.Cut(p, "="); strings.EqualFold(n, "FBTYPE") {
+					fbType = FBType(strings.ToUpper(v))
+				}
+			}
+			set := fb.Periods[fbType]
+			if set == nil {
+				set = timespanset.Empty()
+				fb.Periods[fbType] = set
+			}
+			for _, period := range strings.Split(value, ",") {
+				start, end, perr := parsePeriod(period, loc)
+				if perr != nil {
+					return nil, perr
+				}
+				set.Insert(start, end)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, set := range fb.Periods {
+		if set.Empty() {
+			continue
+		}
+		start, end := set.Extent()
+		if fb.Start.IsZero() || start.Before(fb.Start) {
+			fb.Start = start
+		}
+		if fb.End.IsZero() || end.After(fb.End) {
+			fb.End = end
+		}
+	}
+	return fb, nil
+}
+
+// parsePeriod parses a period of time in the "start/end" or "start/duration"
+// form.
+func parsePeriod(period string, loc *time.Location) (start, end time.Time, err error) {
+	s, e, ok := strings.Cut(period, "/")
+	if !ok {
+		return time.Time{}, time.Time{}, fmt.Errorf("ical: invalid period %q", period)
+	}
+	if start, _, err = parseDateTime(s, loc); err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if strings.HasPrefix(e, "P") || strings.HasPrefix(e, "+P") {
+		days, d, err := parseDuration(e)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		return start, start.AddDate(0, 0, days).Add(d), nil
+	}
+	if end, _, err = parseDateTime(e, loc); err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if end.Before(start) {
+		return time.Time{}, time.Time{}, fmt.Errorf("ical: period %q ends before it starts", period)
+	}
+	return start, end, nil
+}
+
+func formatUTC(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+func floorSecond(t time.Time) time.Time {
+	return t.Truncate(time.Second)
+}
+
+func ceilSecond(t time.Time) time.Time {
+	if f := t.Truncate(time.Second); !f.Equal(t) {
+		return f.Add(time.Second)
+	}
+	return t
+}
+
+// formatDuration formats a whole number of seconds as a DURATION value such
+// as "P1DT2H30M".
+func formatDuration(d time.Duration) string {
+	secs := int64(d / time.Second)
+	days, secs := secs/86400, secs%86400
+	s := "P"
+	if days > 0 {
+		s += fmt.Sprintf("%dD", days)
+	}
+	if secs > 0 || days == 0 {
+		s += "T"
+		if h := secs / 3600; h > 0 {
+			s += fmt.Sprintf("%dH", h)
+		}
+		if m := secs % 3600 / 60; m > 0 {
+			s += fmt.Sprintf("%dM", m)
+		}
+		if sec := secs % 60; sec > 0 || secs == 0 {
+			s += fmt.Sprintf("%dS", sec)
+		}
+	}
+	return s
+}
+
+// fold splits a content line into lines of at most 75 octets, each ending in
+// CRLF, with continuation lines starting with a space.
+func fold(line string) string {
+	const max = 75
+	var b strings.Builder
+	for first := true; ; first = false {
+		limit := max
+		if !first {
+			b.WriteByte(' ')
+			limit--
+		}
+		if len(line) <= limit {
+			b.WriteString(line)
+			b.WriteString("\r\n")
+			return b.String()
+		}
+		// Do not split a multi-byte UTF-8 sequence.
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n")
+		line = line[cut:]
+	}
+}
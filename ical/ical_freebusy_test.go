@@ -0,0 +1,141 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-intervals/timespanset"
+)
+
+func utc(month time.Month, day, hour, min int) time.Time {
+	return time.Date(1997, month, day, hour, min, 0, 0, time.UTC)
+}
+
+func TestEncodeFreeBusy(t *testing.T) {
+	busy := timespanset.Empty()
+	busy.Insert(utc(3, 8, 16, 0), utc(3, 9, 0, 30))
+	busy.Insert(utc(3, 9, 10, 0), utc(3, 9, 11, 0).Add(-time.Millisecond))
+	busy.Insert(utc(3, 20, 0, 0), utc(3, 21, 0, 0))
+	tentative := timespanset.Empty()
+	tentative.Insert(utc(3, 9, 13, 0), utc(3, 9, 14, 0))
+
+	// Times in other locations are converted to UTC.
+	start := time.Date(1997, 3, 8, 0, 0, 0, 0, time.FixedZone("UTC-5", -5*60*60))
+	fb := &FreeBusy{
+		UID:     "19970901T082949Z-FA43EF@example.com",
+		Stamp:   utc(3, 1, 8, 30),
+		Start:   start,
+		End:     utc(3, 10, 0, 0),
+		Periods: map[FBType]*timespanset.Set{Busy: busy, BusyTentative: tentative},
+	}
+
+	var b strings.Builder
+	if err := fb.Encode(&b, PeriodWithDuration); err != nil {
+		t.Fatal(err)
+	}
+	want := strings.Join([]string{
+		"BEGIN:VFREEBUSY",
+		"UID:19970901T082949Z-FA43EF@example.com",
+		"DTSTAMP:19970301T083000Z",
+		"DTSTART:19970308T050000Z",
+		"DTEND:19970310T000000Z",
+		"FREEBUSY;FBTYPE=BUSY:19970308T160000Z/PT8H30M,19970309T100000Z/PT1H",
+		"FREEBUSY;FBTYPE=BUSY-TENTATIVE:19970309T130000Z/PT1H",
+		"END:VFREEBUSY",
+		"",
+	}, "\r\n")
+	if got := b.String(); got != want {
+		t.Errorf("Encode() =\n%s\nwant\n%s", got, want)
+	}
+
+	b.Reset()
+	fb.Start = utc(3, 1, 0, 0)
+	fb.End = utc(4, 1, 0, 0)
+	if err := fb.Encode(&b, PeriodWithEnd); err != nil {
+		t.Fatal(err)
+	}
+	want = strings.Join([]string{
+		"BEGIN:VFREEBUSY",
+		"UID:19970901T082949Z-FA43EF@example.com",
+		"DTSTAMP:19970301T083000Z",
+		"DTSTART:19970301T000000Z",
+		"DTEND:19970401T000000Z",
+		"FREEBUSY;FBTYPE=BUSY:19970308T160000Z/19970309T003000Z,19970309T100000Z/199",
+		" 70309T110000Z,19970320T000000Z/19970321T000000Z",
+		"FREEBUSY;FBTYPE=BUSY-TENTATIVE:19970309T130000Z/19970309T140000Z",
+		"END:VFREEBUSY",
+		"",
+	}, "\r\n")
+	if got := b.String(); got != want {
+		t.Errorf("Encode() =\n%s\nwant\n%s", got, want)
+	}
+
+	decoded, err := ParseFreeBusy(b.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	busy.Insert(utc(3, 9, 11, 0).Add(-time.Millisecond), utc(3, 9, 11, 0))
+	if !decoded.Start.Equal(fb.Start) || !decoded.End.Equal(fb.End) || decoded.UID != fb.UID {
+		t.Errorf("ParseFreeBusy() = %+v, want %+v", decoded, fb)
+	}
+	for fbType, want := range fb.Periods {
+		if got := decoded.Periods[fbType]; got.String() != want.String() {
+			t.Errorf("ParseFreeBusy() %s = %s, want %s", fbType, got, want)
+		}
+	}
+}
+
+func TestParseFreeBusy(t *testing.T) {
+	fb, err := ParseFreeBusy(strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VFREEBUSY",
+		"FREEBUSY:19970308T160000Z/PT3H,19970308T190000Z/19970308T200000",
+		"FREEBUSY;FBTYPE=FREE:19970308T160000Z/PT3H",
+		"FREEBUSY;FBTYPE=free;TZID=America/New_York:19970309T",
+		" 090000/PT1H",
+		"END:VFREEBUSY",
+		"END:VCALENDAR",
+	}, "\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := fb.Start, utc(3, 8, 16, 0); !got.Equal(want) {
+		t.Errorf("Start = %s, want %s", got, want)
+	}
+	if got, want := fb.End, utc(3, 9, 15, 0); !got.Equal(want) {
+		t.Errorf("End = %s, want %s", got, want)
+	}
+	wantBusy := timespanset.Empty()
+	wantBusy.Insert(utc(3, 8, 16, 0), utc(3, 8, 20, 0))
+	if got := fb.Periods[Busy]; got.String() != wantBusy.String() {
+		t.Errorf("busy = %s, want %s", got, wantBusy)
+	}
+	if got := fb.Periods[Free]; !got.Contains(utc(3, 9, 14, 0), utc(3, 9, 15, 0)) || !got.Contains(utc(3, 8, 16, 0), utc(3, 8, 19, 0)) {
+		t.Errorf("free = %s", got)
+	}
+
+	for _, text := range []string{
+		"FREEBUSY:19970308T160000Z",
+		"FREEBUSY:19970308T160000Z/19970308T150000Z",
+		"FREEBUSY:19970308T160000Z/P1X",
+	} {
+		if _, err := ParseFreeBusy(text); err == nil {
+			t.Errorf("ParseFreeBusy(%q) = nil error, want error", text)
+		}
+	}
+}
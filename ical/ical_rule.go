@@ -0,0 +1,516 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ical
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency is the FREQ of a recurrence rule.
+type Frequency int
+
+// Frequencies, from the shortest period to the longest.
+const (
+	Secondly Frequency = iota
+	Minutely
+	Hourly
+	Daily
+	Weekly
+	Monthly
+	Yearly
+)
+
+var frequencyNames = []string{"SECONDLY", "MINUTELY", "HOURLY", "DAILY", "WEEKLY", "MONTHLY", "YEARLY"}
+
+// String returns the name of the frequency as used in RRULE, such as "DAILY".
+func (f Frequency) String() string {
+	if f < 0 || int(f) >= len(frequencyNames) {
+		return fmt.Sprintf("Frequency(%d)", int(f))
+	}
+	return frequencyNames[f]
+}
+
+// maxPeriod returns an upper bound on the length of a period of the frequency,
+// allowing for leap years and daylight saving transitions.
+func (f Frequency) maxPeriod() time.Duration {
+	const day = 24 * time.Hour
+	return []time.Duration{
+		time.Second,
+		time.Minute,
+		time.Hour,
+		day + time.Hour,
+		7*day + time.Hour,
+		31*day + time.Hour,
+		366*day + time.Hour,
+	}[f]
+}
+
+var weekdayNames = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// WeekdayNum is an entry of BYDAY, such as "MO", "1MO" or "-1FR". N is zero
+// unless an ordinal is given, in which case it selects the Nth occurrence of
+// the weekday within the month or year, counting from the end if N is
+// negative.
+type WeekdayNum struct {
+	N   int
+	Day time.Weekday
+}
+
+// Rule is a recurrence rule (RRULE) as defined by RFC 5545.
+//
+// The BYWEEKNO and BYYEARDAY parts are not supported.
+type Rule struct {
+	Freq     Frequency
+	Interval int
+
+	// Count is the number of occurrences generated by the rule, or zero if it
+	// is unbounded.
+	Count int
+
+	// Until is the last possible occurrence of the rule, inclusive, or the zero
+	// time if it is unbounded.
+	Until time.Time
+
+	ByMonth    []time.Month
+	ByMonthDay []int
+	ByDay      []WeekdayNum
+	ByHour     []int
+	ByMinute   []int
+	BySecond   []int
+	BySetPos   []int
+	WeekStart  time.Weekday
+}
+
+// ParseRule parses the value of an RRULE property, such as
+// "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE". A floating or date-only UNTIL is
+// interpreted in loc; a date-only UNTIL includes the whole day.
+func ParseRule(text string, loc *time.Location) (*Rule, error) {
+	r := &Rule{Freq: -1, Interval: 1, WeekStart: time.Monday}
+	for _, part := range strings.Split(strings.TrimSpace(text), ";") {
+		name, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("ical: invalid rule part %q", part)
+		}
+		var err error
+		switch strings.ToUpper(name) {
+		case "FREQ":
+			r.Freq = -1
+			for i, n := range frequencyNames {
+				if strings.EqualFold(value, n) {
+					r.Freq = Frequency(i)
+				}
+			}
+			if r.Freq < 0 {
+				err = fmt.Errorf("unknown frequency")
+			}
+		case "INTERVAL":
+			r.Interval, err = strconv.Atoi(value)
+			if err == nil && r.Interval < 1 {
+				err = fmt.Errorf("interval must be positive")
+			}
+		case "COUNT":
+			r.Count, err = strconv.Atoi(value)
+			if err == nil && r.Count < 1 {
+				err = fmt.Errorf("count must be positive")
+			}
+		case "UNTIL":
+			var isDate bool
+			r.Until, isDate, err = parseDateTime(value, loc)
+			if isDate {
+				r.Until = r.Until.AddDate(0, 0, 1).Add(-time.Nanosecond)
+			}
+		case "BYMONTH":
+			var months []int
+			months, err = parseInts(value, 1, 12, false)
+			for _, m := range months {
+				r.ByMonth = append(r.ByMonth, time.Month(m))
+			}
+		case "BYMONTHDAY":
+			r.ByMonthDay, err = parseInts(value, 1, 31, true)
+		case "BYDAY":
+			r.ByDay, err = parseWeekdayNums(value)
+		case "BYHOUR":
+			r.ByHour, err = parseInts(value, 0, 23, false)
+		case "BYMINUTE":
+			r.ByMinute, err = parseInts(value, 0, 59, false)
+		case "BYSECOND":
+			r.BySecond, err = parseInts(value, 0, 60, false)
+		case "BYSETPOS":
+			r.BySetPos, err = parseInts(value, 1, 366, true)
+		case "WKST":
+			var days []WeekdayNum
+			days, err = parseWeekdayNums(value)
+			if err == nil && (len(days) != 1 || days[0].N != 0) {
+				err = fmt.Errorf("invalid week start")
+			}
+			if err == nil {
+				r.WeekStart = days[0].Day
+			}
+		case "BYWEEKNO", "BYYEARDAY":
+			err = fmt.Errorf("not supported")
+		default:
+			err = fmt.Errorf("unknown rule part")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("ical: invalid rule part %q: %v", part, err)
+		}
+	}
+	if r.Freq < 0 {
+		return nil, fmt.Errorf("ical: rule %q has no FREQ", text)
+	}
+	if r.Count > 0 && !r.Until.IsZero() {
+		return nil, fmt.Errorf("ical: rule %q has both COUNT and UNTIL", text)
+	}
+	for _, d := range r.ByDay {
+		if d.N != 0 && r.Freq != Monthly && r.Freq != Yearly {
+			return nil, fmt.Errorf("ical: rule %q uses a BYDAY ordinal with frequency %s", text, r.Freq)
+		}
+	}
+	return r, nil
+}
+
+// parseInts parses a comma-separated list of integers whose absolute values
+// are in [lo, hi]. Negative values are only accepted if negative is true.
+func parseInts(value string, lo, hi int, negative bool) ([]int, error) {
+	var result []int
+	for _, s := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return nil, err
+		}
+		abs := n
+		if n < 0 && negative {
+			abs = -n
+		}
+		if abs < lo || abs > hi {
+			return nil, fmt.Errorf("%d out of range", n)
+		}
+		result = append(result, n)
+	}
+	return result, nil
+}
+
+func parseWeekdayNums(value string) ([]WeekdayNum, error) {
+	var result []WeekdayNum
+	for _, s := range strings.Split(value, ",") {
+		if len(s) < 2 {
+			return nil, fmt.Errorf("invalid weekday %q", s)
+		}
+		d := WeekdayNum{Day: -1}
+		for i, name := range weekdayNames {
+			if strings.EqualFold(s[len(s)-2:], name) {
+				d.Day = time.Weekday(i)
+			}
+		}
+		if d.Day < 0 {
+			return nil, fmt.Errorf("invalid weekday %q", s)
+		}
+		if n := s[:len(s)-2]; n != "" {
+			var err error
+			if d.N, err = strconv.Atoi(n); err != nil || d.N == 0 || d.N < -53 || d.N > 53 {
+				return nil, fmt.Errorf("invalid weekday %q", s)
+			}
+		}
+		result = append(result, d)
+	}
+	return result, nil
+}
+
+// ruleIterator generates the occurrences of a rule in increasing order.
+type ruleIterator struct {
+	rule    *Rule
+	dtstart time.Time
+	loc     *time.Location
+
+	period  int
+	pending []time.Time
+	emitted int
+	done    bool
+}
+
+func newRuleIterator(r *Rule, dtstart time.Time) *ruleIterator {
+	return &ruleIterator{rule: r, dtstart: dtstart, loc: dtstart.Location()}
+}
+
+// skipTo advances the iterator past periods that end before t. It has no
+// effect on rules with a COUNT, which must be counted from the start.
+func (it *ruleIterator) skipTo(t time.Time) {
+	if it.rule.Count > 0 || it.period > 0 || !t.After(it.dtstart) {
+		return
+	}
+	// Period k starts no later than dtstart + k * interval * maxPeriod, so
+	// every period before k ends before t.
+	k := int(t.Sub(it.dtstart)/(time.Duration(it.rule.Interval)*it.rule.Freq.maxPeriod())) - 1
+	if k > 0 {
+		it.period = k
+	}
+}
+
+// next returns the next occurrence of the rule. The second return value is
+// false if there are no more occurrences that start before limit.
+func (it *ruleIterator) next(limit time.Time) (time.Time, bool) {
+	for len(it.pending) == 0 {
+		if it.done {
+			return time.Time{}, false
+		}
+		start, candidates := it.expand(it.period)
+		if !start.Before(limit) {
+			return time.Time{}, false
+		}
+		it.period++
+		for _, c := range candidates {
+			if c.Before(it.dtstart) {
+				continue
+			}
+			if !it.rule.Until.IsZero() && c.After(it.rule.Until) {
+				it.done = true
+				break
+			}
+			it.pending = append(it.pending, c)
+			it.emitted++
+			if it.rule.Count > 0 && it.emitted == it.rule.Count {
+				it.done = true
+				break
+			}
+		}
+	}
+	t := it.pending[0]
+	it.pending = it.pending[1:]
+	return t, true
+}
+
+// expand returns the start of period k of the rule and the sorted candidate
+// occurrences within it, after applying BYSETPOS.
+func (it *ruleIterator) expand(k int) (time.Time, []time.Time) {
+	r, dt := it.rule, it.dtstart
+	n := k * r.Interval
+	var start time.Time
+	var days []time.Time
+	switch r.Freq {
+	case Yearly:
+		start = time.Date(dt.Year()+n, time.January, 1, 0, 0, 0, 0, it.loc)
+		for d := start; d.Year() == start.Year(); d = d.AddDate(0, 0, 1) {
+			if it.yearlyDay(d) {
+				days = append(days, d)
+			}
+		}
+	case Monthly:
+		start = time.Date(dt.Year(), dt.Month()+time.Month(n), 1, 0, 0, 0, 0, it.loc)
+		for d := start; d.Month() == start.Month(); d = d.AddDate(0, 0, 1) {
+			if it.monthlyDay(d) {
+				days = append(days, d)
+			}
+		}
+	case Weekly:
+		offset := (int(dt.Weekday()) - int(r.WeekStart) + 7) % 7
+		start = time.Date(dt.Year(), dt.Month(), dt.Day()-offset+7*n, 0, 0, 0, 0, it.loc)
+		for i := 0; i < 7; i++ {
+			d := start.AddDate(0, 0, i)
+			if matchMonth(r.ByMonth, d) && it.matchWeekday(d) {
+				days = append(days, d)
+			}
+		}
+	case Daily:
+		start = time.Date(dt.Year(), dt.Month(), dt.Day()+n, 0, 0, 0, 0, it.loc)
+		if it.limitDay(start) {
+			days = append(days, start)
+		}
+	default:
+		// Sub-daily periods are measured in elapsed time from DTSTART.
+		unit := []time.Duration{time.Second, time.Minute, time.Hour}[r.Freq]
+		start = dt.Truncate(unit).Add(time.Duration(n) * unit).In(it.loc)
+		if !it.limitDay(start) ||
+			(r.Freq < Hourly && !matchInt(r.ByMinute, start.Minute())) ||
+			!matchInt(r.ByHour, start.Hour()) ||
+			(r.Freq == Secondly && !matchInt(r.BySecond, start.Second())) {
+			return start, nil
+		}
+		days = []time.Time{start}
+	}
+
+	var candidates []time.Time
+	for _, d := range days {
+		for _, h := range it.values(r.ByHour, dt.Hour(), d.Hour(), Hourly) {
+			for _, m := range it.values(r.ByMinute, dt.Minute(), d.Minute(), Minutely) {
+				for _, s := range it.values(r.BySecond, dt.Second(), d.Second(), Secondly) {
+					candidates = append(candidates, time.Date(d.Year(), d.Month(), d.Day(), h, m, s, 0, it.loc))
+				}
+			}
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].Before(candidates[j]) })
+	return start, setPos(r.BySetPos, candidates)
+}
+
+// values returns the values of a time component for the candidates of a
+// period. If the rule's frequency is at least as fine as the component, the
+// component of the period start is used; otherwise the BYxxx values expand
+// the candidates, defaulting to the component of DTSTART.
+func (it *ruleIterator) values(by []int, fromStart, fromPeriod int, component Frequency) []int {
+	if it.rule.Freq <= component {
+		return []int{fromPeriod}
+	}
+	if len(by) > 0 {
+		return by
+	}
+	return []int{fromStart}
+}
+
+func (it *ruleIterator) yearlyDay(d time.Time) bool {
+	r, dt := it.rule, it.dtstart
+	if !matchMonth(r.ByMonth, d) {
+		return false
+	}
+	if len(r.ByMonthDay) == 0 && len(r.ByDay) == 0 {
+		if len(r.ByMonth) == 0 && d.Month() != dt.Month() {
+			return false
+		}
+		return d.Day() == dt.Day()
+	}
+	if !matchMonthDay(r.ByMonthDay, d) {
+		return false
+	}
+	if len(r.ByMonth) > 0 {
+		first, last := monthBounds(d)
+		return matchWeekdayNum(r.ByDay, d, first, last)
+	}
+	first := time.Date(d.Year(), time.January, 1, 0, 0, 0, 0, d.Location())
+	return matchWeekdayNum(r.ByDay, d, first, first.AddDate(1, 0, -1))
+}
+
+func (it *ruleIterator) monthlyDay(d time.Time) bool {
+	r := it.rule
+	if !matchMonth(r.ByMonth, d) {
+		return false
+	}
+	if len(r.ByMonthDay) == 0 && len(r.ByDay) == 0 {
+		return d.Day() == it.dtstart.Day()
+	}
+	first, last := monthBounds(d)
+	return matchMonthDay(r.ByMonthDay, d) && matchWeekdayNum(r.ByDay, d, first, last)
+}
+
+func (it *ruleIterator) matchWeekday(d time.Time) bool {
+	if len(it.rule.ByDay) == 0 {
+		return d.Weekday() == it.dtstart.Weekday()
+	}
+	return matchWeekdayNum(it.rule.ByDay, d, d, d)
+}
+
+// limitDay reports whether the date of d satisfies the BYMONTH, BYMONTHDAY and
+// BYDAY limits of the rule.
+func (it *ruleIterator) limitDay(d time.Time) bool {
+	r := it.rule
+	return matchMonth(r.ByMonth, d) && matchMonthDay(r.ByMonthDay, d) && matchWeekdayNum(r.ByDay, d, d, d)
+}
+
+func matchInt(by []int, x int) bool {
+	if len(by) == 0 {
+		return true
+	}
+	for _, b := range by {
+		if b == x {
+			return true
+		}
+	}
+	return false
+}
+
+func matchMonth(by []time.Month, d time.Time) bool {
+	if len(by) == 0 {
+		return true
+	}
+	for _, m := range by {
+		if m == d.Month() {
+			return true
+		}
+	}
+	return false
+}
+
+func monthBounds(d time.Time) (first, last time.Time) {
+	first = time.Date(d.Year(), d.Month(), 1, 0, 0, 0, 0, d.Location())
+	return first, first.AddDate(0, 1, -1)
+}
+
+func matchMonthDay(by []int, d time.Time) bool {
+	if len(by) == 0 {
+		return true
+	}
+	_, last := monthBounds(d)
+	for _, n := range by {
+		if n == d.Day() || n == d.Day()-last.Day()-1 {
+			return true
+		}
+	}
+	return false
+}
+
+// matchWeekdayNum reports whether d matches an entry of BYDAY, where ordinals
+// count occurrences of the weekday between the dates first and last.
+func matchWeekdayNum(by []WeekdayNum, d, first, last time.Time) bool {
+	if len(by) == 0 {
+		return true
+	}
+	for _, w := range by {
+		if w.Day != d.Weekday() {
+			continue
+		}
+		switch {
+		case w.N == 0:
+			return true
+		case w.N > 0 && daysBetween(first, d)/7+1 == w.N:
+			return true
+		case w.N < 0 && daysBetween(d, last)/7+1 == -w.N:
+			return true
+		}
+	}
+	return false
+}
+
+// daysBetween returns the number of calendar days from a to b.
+func daysBetween(a, b time.Time) int {
+	ua := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	ub := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	return int(ub.Sub(ua) / (24 * time.Hour))
+}
+
+// setPos returns the candidates selected by BYSETPOS, in increasing order.
+func setPos(by []int, candidates []time.Time) []time.Time {
+	if len(by) == 0 {
+		return candidates
+	}
+	selected := make([]bool, len(candidates))
+	for _, p := range by {
+		i := p - 1
+		if p < 0 {
+			i = len(candidates) + p
+		}
+		if 0 <= i && i < len(candidates) {
+			selected[i] = true
+		}
+	}
+	var result []time.Time
+	for i, c := range candidates {
+		if selected[i] {
+			result = append(result, c)
+		}
+	}
+	return result
+}
@@ -0,0 +1,267 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ical
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-intervals/timespanset"
+)
+
+var (
+	past   = time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)
+	future = time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func mustParseEvent(t *testing.T, lines ...string) *Event {
+	t.Helper()
+	e, err := ParseEvent(strings.Join(lines, "\r\n"), time.UTC)
+	if err != nil {
+		t.Fatalf("ParseEvent() = %v", err)
+	}
+	return e
+}
+
+func occurrences(e *Event, start, end time.Time, max int) []string {
+	var result []string
+	e.Occurrences(start, end, func(t time.Time) bool {
+		result = append(result, t.Format("2006-01-02 15:04 MST"))
+		return len(result) < max
+	})
+	return result
+}
+
+// Most of these cases are examples from RFC 5545, section 3.8.5.3.
+func TestRuleExamples(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		rrule string
+		extra []string
+		max   int
+		want  []string
+	}{
+		{
+			name:  "daily for 10 occurrences",
+			rrule: "FREQ=DAILY;COUNT=10",
+			want: []string{
+				"1997-09-02 09:00 EDT", "1997-09-03 09:00 EDT", "1997-09-04 09:00 EDT", "1997-09-05 09:00 EDT",
+				"1997-09-06 09:00 EDT", "1997-09-07 09:00 EDT", "1997-09-08 09:00 EDT", "1997-09-09 09:00 EDT",
+				"1997-09-10 09:00 EDT", "1997-09-11 09:00 EDT",
+			},
+		},
+		{
+			name:  "every 10 days across daylight saving",
+			rrule: "FREQ=DAILY;INTERVAL=10;COUNT=7",
+			want: []string{
+				"1997-09-02 09:00 EDT", "1997-09-12 09:00 EDT", "1997-09-22 09:00 EDT", "1997-10-02 09:00 EDT",
+				"1997-10-12 09:00 EDT", "1997-10-22 09:00 EDT", "1997-11-01 09:00 EST",
+			},
+		},
+		{
+			name:  "weekly on Tuesday and Thursday for five weeks",
+			rrule: "FREQ=WEEKLY;UNTIL=19971007T000000Z;WKST=SU;BYDAY=TU,TH",
+			want: []string{
+				"1997-09-02 09:00 EDT", "1997-09-04 09:00 EDT", "1997-09-09 09:00 EDT", "1997-09-11 09:00 EDT",
+				"1997-09-16 09:00 EDT", "1997-09-18 09:00 EDT", "1997-09-23 09:00 EDT", "1997-09-25 09:00 EDT",
+				"1997-09-30 09:00 EDT", "1997-10-02 09:00 EDT",
+			},
+		},
+		{
+			name:  "every other week on Tuesday and Thursday",
+			rrule: "FREQ=WEEKLY;INTERVAL=2;COUNT=8;WKST=SU;BYDAY=TU,TH",
+			want: []string{
+				"1997-09-02 09:00 EDT", "1997-09-04 09:00 EDT", "1997-09-16 09:00 EDT", "1997-09-18 09:00 EDT",
+				"1997-09-30 09:00 EDT", "1997-10-02 09:00 EDT", "1997-10-14 09:00 EDT", "1997-10-16 09:00 EDT",
+			},
+		},
+		{
+			name:  "monthly on the first Friday",
+			rrule: "FREQ=MONTHLY;COUNT=10;BYDAY=1FR",
+			extra: []string{"EXDATE;TZID=America/New_York:19970902T090000"},
+			want: []string{
+				"1997-09-05 09:00 EDT", "1997-10-03 09:00 EDT", "1997-11-07 09:00 EST", "1997-12-05 09:00 EST",
+				"1998-01-02 09:00 EST", "1998-02-06 09:00 EST", "1998-03-06 09:00 EST", "1998-04-03 09:00 EST",
+				"1998-05-01 09:00 EDT", "1998-06-05 09:00 EDT",
+			},
+		},
+		{
+			name:  "monthly on the second-to-last Monday",
+			rrule: "FREQ=MONTHLY;COUNT=6;BYDAY=-2MO",
+			extra: []string{"EXDATE;TZID=America/New_York:19970902T090000"},
+			want: []string{
+				"1997-09-22 09:00 EDT", "1997-10-20 09:00 EDT", "1997-11-17 09:00 EST", "1997-12-22 09:00 EST",
+				"1998-01-19 09:00 EST", "1998-02-16 09:00 EST",
+			},
+		},
+		{
+			name:  "monthly on the 2nd and 15th",
+			rrule: "FREQ=MONTHLY;COUNT=10;BYMONTHDAY=2,15",
+			want: []string{
+				"1997-09-02 09:00 EDT", "1997-09-15 09:00 EDT", "1997-10-02 09:00 EDT", "1997-10-15 09:00 EDT",
+				"1997-11-02 09:00 EST", "1997-11-15 09:00 EST", "1997-12-02 09:00 EST", "1997-12-15 09:00 EST",
+				"1998-01-02 09:00 EST", "1998-01-15 09:00 EST",
+			},
+		},
+		{
+			name:  "monthly on the last day",
+			rrule: "FREQ=MONTHLY;BYMONTHDAY=-1",
+			extra: []string{"EXDATE;TZID=America/New_York:19970902T090000"},
+			max:   4,
+			want:  []string{"1997-09-30 09:00 EDT", "1997-10-31 09:00 EST", "1997-11-30 09:00 EST", "1997-12-31 09:00 EST"},
+		},
+		{
+			name:  "yearly in June and July",
+			rrule: "FREQ=YEARLY;COUNT=6;BYMONTH=6,7",
+			extra: []string{"EXDATE;TZID=America/New_York:19970902T090000"},
+			want: []string{
+				"1998-06-02 09:00 EDT", "1998-07-02 09:00 EDT", "1999-06-02 09:00 EDT", "1999-07-02 09:00 EDT",
+				"2000-06-02 09:00 EDT", "2000-07-02 09:00 EDT",
+			},
+		},
+		{
+			name:  "every Friday the 13th",
+			rrule: "FREQ=MONTHLY;BYDAY=FR;BYMONTHDAY=13",
+			extra: []string{"EXDATE;TZID=America/New_York:19970902T090000"},
+			max:   5,
+			want: []string{
+				"1998-02-13 09:00 EST", "1998-03-13 09:00 EST", "1998-11-13 09:00 EST", "1999-08-13 09:00 EDT",
+				"2000-10-13 09:00 EDT",
+			},
+		},
+		{
+			name:  "US Thanksgiving",
+			rrule: "FREQ=YEARLY;BYMONTH=11;BYDAY=4TH",
+			extra: []string{"EXDATE;TZID=America/New_York:19970902T090000"},
+			max:   3,
+			want:  []string{"1997-11-27 09:00 EST", "1998-11-26 09:00 EST", "1999-11-25 09:00 EST"},
+		},
+		{
+			name:  "20th Monday of the year",
+			rrule: "FREQ=YEARLY;BYDAY=20MO",
+			extra: []string{"EXDATE;TZID=America/New_York:19970902T090000"},
+			max:   3,
+			want:  []string{"1998-05-18 09:00 EDT", "1999-05-17 09:00 EDT", "2000-05-15 09:00 EDT"},
+		},
+		{
+			name:  "last work day of the month",
+			rrule: "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1",
+			extra: []string{"EXDATE;TZID=America/New_York:19970902T090000"},
+			max:   4,
+			want:  []string{"1997-09-30 09:00 EDT", "1997-10-31 09:00 EST", "1997-11-28 09:00 EST", "1997-12-31 09:00 EST"},
+		},
+		{
+			name:  "every 20 minutes from 9:00 to 16:40",
+			rrule: "FREQ=DAILY;BYHOUR=9,10,11,12,13,14,15,16;BYMINUTE=0,20,40",
+			max:   5,
+			want: []string{
+				"1997-09-02 09:00 EDT", "1997-09-02 09:20 EDT", "1997-09-02 09:40 EDT", "1997-09-02 10:00 EDT",
+				"1997-09-02 10:20 EDT",
+			},
+		},
+		{
+			name:  "every 90 minutes",
+			rrule: "FREQ=MINUTELY;INTERVAL=90;COUNT=4",
+			want:  []string{"1997-09-02 09:00 EDT", "1997-09-02 10:30 EDT", "1997-09-02 12:00 EDT", "1997-09-02 13:30 EDT"},
+		},
+		{
+			name:  "with RDATE",
+			rrule: "FREQ=WEEKLY;COUNT=2",
+			extra: []string{"RDATE;TZID=America/New_York:19970903T120000,19970909T090000"},
+			want:  []string{"1997-09-02 09:00 EDT", "1997-09-03 12:00 EDT", "1997-09-09 09:00 EDT"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := append([]string{
+				"BEGIN:VEVENT",
+				"DTSTART;TZID=America/New_York:19970902T090000",
+				"RRULE:" + tt.rrule,
+			}, tt.extra...)
+			e := mustParseEvent(t, append(lines, "END:VEVENT")...)
+			max := tt.max
+			if max == 0 {
+				max = 100
+			}
+			if got := occurrences(e, past, future, max); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("occurrences = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIntervalsBetween(t *testing.T) {
+	// A weekly one-hour meeting with no end, folded over two lines.
+	e := mustParseEvent(t,
+		"DTSTART:20260105T170000Z",
+		"DURATION:PT1H",
+		"RRULE:FREQ=WEEKLY;BYDAY=MO,",
+		" WE",
+	)
+	start := time.Date(2040, 3, 1, 0, 0, 0, 0, time.UTC)
+	set := timespanset.Between(e, start, start.AddDate(0, 0, 7))
+	want := "{[2040-03-05 17:00:00 +0000 UTC, 2040-03-05 18:00:00 +0000 UTC), [2040-03-07 17:00:00 +0000 UTC, 2040-03-07 18:00:00 +0000 UTC)}"
+	if got := set.String(); got != want {
+		t.Errorf("Between() = %s, want %s", got, want)
+	}
+
+	// Overlapping occurrences are combined and truncated to the query range.
+	e = mustParseEvent(t,
+		"DTSTART:20260101T000000Z",
+		"DTEND:20260101T020000Z",
+		"RRULE:FREQ=HOURLY;BYHOUR=0,1,2,8",
+	)
+	var got []string
+	e.IntervalsBetween(time.Date(2026, 1, 2, 1, 0, 0, 0, time.UTC), time.Date(2026, 1, 2, 9, 0, 0, 0, time.UTC), func(s, e time.Time) bool {
+		got = append(got, s.Format("15:04")+"-"+e.Format("15:04"))
+		return true
+	})
+	if want := []string{"01:00-04:00", "08:00-09:00"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("IntervalsBetween() = %v, want %v", got, want)
+	}
+
+	// An all-day event lasts a calendar day, even when it is 23 hours long.
+	e = mustParseEvent(t,
+		"DTSTART;VALUE=DATE;TZID=America/New_York:20260307",
+		"RRULE:FREQ=DAILY;COUNT=2",
+	)
+	var lengths []time.Duration
+	e.IntervalsBetween(past, future, func(s, e time.Time) bool {
+		lengths = append(lengths, e.Sub(s))
+		return true
+	})
+	if want := []time.Duration{47 * time.Hour}; !reflect.DeepEqual(lengths, want) {
+		t.Errorf("IntervalsBetween() lengths = %v, want %v", lengths, want)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, text := range []string{
+		"RRULE:FREQ=DAILY",
+		"DTSTART:2026",
+		"DTSTART;TZID=Nowhere/Special:20260101T000000",
+		"DTSTART:20260101T000000Z\nRRULE:FREQ=SOMETIMES",
+		"DTSTART:20260101T000000Z\nRRULE:FREQ=DAILY;COUNT=2;UNTIL=20270101T000000Z",
+		"DTSTART:20260101T000000Z\nRRULE:FREQ=WEEKLY;BYDAY=1MO",
+		"DTSTART:20260101T000000Z\nRRULE:FREQ=YEARLY;BYWEEKNO=20",
+		"DTSTART:20260101T000000Z\nDURATION:P1H",
+		"DTSTART:20260101T000000Z\nDTEND:20251231T000000Z",
+	} {
+		if _, err := ParseEvent(text, time.UTC); err == nil {
+			t.Errorf("ParseEvent(%q) = nil error, want error", text)
+		}
+	}
+}
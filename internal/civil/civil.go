@@ -0,0 +1,43 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package civil holds calendar computations shared by the dateset and
+// timespanset packages.
+package civil
+
+import "time"
+
+// StartOfDay returns the first instant of a day in loc. This is usually
+// midnight, but is later if a daylight saving transition skips midnight. Like
+// time.Date, it normalizes month and day values outside their usual ranges, so
+// that October 32 is November 1.
+func StartOfDay(year int, month time.Month, day int, loc *time.Location) time.Time {
+	t := time.Date(year, month, day, 0, 0, 0, 0, loc)
+	_, _, d := time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Date()
+	if t.Day() == d {
+		return t
+	}
+	// Midnight does not exist, and t is on the previous day. The day starts
+	// at the transition, which is found by bisecting the following hours.
+	lo, hi := t, t.Add(3*time.Hour)
+	for hi.Sub(lo) > time.Second {
+		mid := lo.Add(hi.Sub(lo) / 2).Truncate(time.Second)
+		if mid.Day() != d {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return hi
+}
@@ -0,0 +1,44 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package civil
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestStartOfDay(t *testing.T) {
+	// In 2018 Sao Paulo skipped from midnight to 1am on November 4.
+	saoPaulo, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		panic(fmt.Errorf("timezone not available: %v", err))
+	}
+	for _, tt := range []struct {
+		year  int
+		month time.Month
+		day   int
+		want  time.Time
+	}{
+		{2018, time.November, 3, time.Date(2018, time.November, 3, 0, 0, 0, 0, saoPaulo)},
+		{2018, time.November, 4, time.Date(2018, time.November, 4, 1, 0, 0, 0, saoPaulo)},
+		{2018, time.October, 35, time.Date(2018, time.November, 4, 1, 0, 0, 0, saoPaulo)},
+		{2018, time.November, 5, time.Date(2018, time.November, 5, 0, 0, 0, 0, saoPaulo)},
+	} {
+		if got := StartOfDay(tt.year, tt.month, tt.day, saoPaulo); !got.Equal(tt.want) {
+			t.Errorf("StartOfDay(%d, %s, %d) = %s, want %s", tt.year, tt.month, tt.day, got, tt.want)
+		}
+	}
+}
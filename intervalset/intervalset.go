@@ -64,6 +64,15 @@ type Set struct {
 	intervals []Interval
 	// factory is needed when the extents of the empty set are needed.
 	factory intervalFactory
+	// observers are notified of each change made to the set.
+	observers []*observer
+	// tx is the open transaction, if any.
+	tx *Tx
+	// history records changes for Undo and Redo, if enabled.
+	history *history
+	// replaying is true while changes are being reverted or reapplied, and
+	// should not be recorded.
+	replaying bool
 }
 
 // SetInput is an interface implemented by Set and ImmutableSet. It is used when
@@ -95,7 +104,8 @@ func NewSetV1(intervals []Interval, makeZero func() Interval) *Set {
 	if err := CheckSorted(intervals); err != nil {
 		panic(err)
 	}
-	return &Set{intervals, makeIntervalFactor(makeZero)}
+	// Copy the intervals because mutating methods may modify the slice in place.
+	return &Set{intervals: append([]Interval(nil), intervals...), factory: makeIntervalFactor(makeZero)}
 }
 
 // CheckSorted checks that interval[i+1] is not before interval[i] for all
@@ -118,12 +128,14 @@ func Empty() *Set {
 // EmptyV1 returns a new, empty set of intervals using the semantics of the V1
 // API, which will require a factory method for construction of an empty interval.
 func EmptyV1(makeZero func() Interval) *Set {
-	return &Set{nil, makeIntervalFactor(makeZero)}
+	return &Set{factory: makeIntervalFactor(makeZero)}
 }
 
-// Copy returns a copy of a set that may be mutated without affecting the original.
+// Copy returns a copy of a set that may be mutated without affecting the
+// original. Observers, the open transaction and the history of s are not
+// copied.
 func (s *Set) Copy() *Set {
-	return &Set{append([]Interval(nil), s.intervals...), s.factory}
+	return &Set{intervals: append([]Interval(nil), s.intervals...), factory: s.factory}
 }
 
 // String returns a human-friendly representation of the set.
@@ -144,19 +156,45 @@ func (s *Set) Extent() Interval {
 	return s.intervals[0].Encompass(s.intervals[len(s.intervals)-1])
 }
 
-// Add adds all the elements of another set to this set.
-func (s *Set) Add(b SetInput) {
+// Add adds all the elements of another set to this set. It returns a patch
+// describing the intervals that were actually added.
+func (s *Set) Add(b SetInput) *Patch {
 	// Deal with nil extent. See https://github.com/google/go-intervals/issues/6.
 	bExtent := b.Extent()
 	if bExtent == nil {
-		return // no changes needed
+		return &Patch{} // no changes needed
 	}
 
-	// Loop through the intervals of x
-	b.IntervalsBetween(bExtent, func(x Interval) bool {
-		s.insert(x)
+	intervals := collect(b, bExtent)
+	if len(intervals) == 1 {
+		// Compute the patch directly, since insert modifies s.intervals in place.
+		p := &Patch{Added: s.uncovered(intervals[0])}
+		s.insert(intervals[0])
+		return s.report(p)
+	}
+	old := s.intervals
+	s.merge(intervals)
+	return s.changed(old, bExtent)
+}
+
+// add adds the intervals of b within bExtent to the set.
+func (s *Set) add(b SetInput, bExtent Interval) {
+	intervals := collect(b, bExtent)
+	if len(intervals) == 1 {
+		s.insert(intervals[0])
+		return
+	}
+	s.merge(intervals)
+}
+
+// collect returns the intervals of b within extent.
+func collect(b SetInput, extent Interval) []Interval {
+	var intervals []Interval
+	b.IntervalsBetween(extent, func(x Interval) bool {
+		intervals = append(intervals, x)
 		return true
 	})
+	return intervals
 }
 
 // Contains reports whether an interval is entirely contained by the set.
@@ -173,6 +211,24 @@ func (s *Set) Contains(ival Interval) bool {
 	return ival.IsZero()
 }
 
+// uncovered returns the portions of ival that are not contained by the set, in
+// increasing order.
+func (s *Set) uncovered(ival Interval) []Interval {
+	var result []Interval
+	next := s.iterator(ival, true)
+	for setInterval := next(); setInterval != nil && !ival.IsZero(); setInterval = next() {
+		left, right := ival.Bisect(setInterval)
+		if !left.IsZero() {
+			result = append(result, left)
+		}
+		ival = right
+	}
+	if !ival.IsZero() {
+		result = append(result, ival)
+	}
+	return result
+}
+
 // adjoinOrAppend adds an interval to the end of intervals unless that value
 // directly adjoins the last element of intervals, in which case the last
 // element will be replaced by the adjoined interval.
@@ -193,60 +249,110 @@ func (s *Set) insert(insertion Interval) {
 	if s.Contains(insertion) {
 		return
 	}
-	// TODO(reddaly): Something like Java's ArrayList would allow both O(log(n))
-	// insertion and O(log(n)) lookup. For now, we have O(log(n)) lookup and O(n)
-	// insertion.
+	// The intervals in s.intervals[low:high] overlap or adjoin the insertion
+	// and are replaced by a single interval. Finding them is O(log(n)), but
+	// shifting the rest of the slice is still O(n).
+	low, high := s.searchLow(insertion), s.searchHigh(insertion)
+	if low > 0 && !s.intervals[low-1].Adjoin(insertion).IsZero() {
+		low--
+	}
+	if high < len(s.intervals) && !insertion.Adjoin(s.intervals[high]).IsZero() {
+		high++
+	}
+	merged := insertion
+	for _, x := range s.intervals[low:high] {
+		merged = merged.Encompass(x)
+	}
+	if low == high {
+		s.intervals = append(s.intervals, nil)
+		copy(s.intervals[low+1:], s.intervals[low:])
+		s.intervals[low] = merged
+		return
+	}
+	s.intervals[low] = merged
+	n := copy(s.intervals[low+1:], s.intervals[high:])
+	for i := low + 1 + n; i < len(s.intervals); i++ {
+		s.intervals[i] = nil // allow garbage collection
+	}
+	s.intervals = s.intervals[:low+1+n]
+}
+
+// merge replaces the intervals of the set with the union of the set and a
+// sorted slice of intervals in O(n + m) time.
+func (s *Set) merge(intervals []Interval) {
 	var newIntervals []Interval
+	var current Interval
 	push := func(x Interval) {
-		newIntervals = adjoinOrAppend(newIntervals, x)
-	}
-	inserted := false
-	for _, x := range s.intervals {
-		if inserted {
-			push(x)
-			continue
-		}
-		if insertion.Before(x) {
-			push(insertion)
-			push(x)
-			inserted = true
-			continue
+		switch {
+		case current == nil:
+			current = x
+		case current.Before(x):
+			newIntervals = adjoinOrAppend(newIntervals, current)
+			current = x
+		default:
+			current = current.Encompass(x)
 		}
-		// [===left===)[==x===)[===right===)
-		left, right := insertion.Bisect(x)
-		if !left.IsZero() {
-			push(left)
-		}
-		push(x)
-		// Replace the interval being inserted with the remaining portion of the
-		// interval to be inserted.
-		if right.IsZero() {
-			inserted = true
-		} else {
-			insertion = right
+	}
+	i, j := 0, 0
+	for i < len(s.intervals) || j < len(intervals) {
+		switch {
+		case j == len(intervals):
+			push(s.intervals[i])
+			i++
+		case i == len(s.intervals):
+			push(intervals[j])
+			j++
+		case s.intervals[i].Before(intervals[j]):
+			push(s.intervals[i])
+			i++
+		case intervals[j].Before(s.intervals[i]):
+			push(intervals[j])
+			j++
+		default:
+			push(s.intervals[i].Encompass(intervals[j]))
+			i++
+			j++
 		}
 	}
-	if !inserted {
-		push(insertion)
+	if current != nil {
+		newIntervals = adjoinOrAppend(newIntervals, current)
 	}
 	s.intervals = newIntervals
 }
 
-// Sub destructively modifies the set by subtracting b.
-func (s *Set) Sub(b SetInput) {
-	extent := s.Extent()
+// Sub destructively modifies the set by subtracting b. It returns a patch
+// describing the intervals that were actually removed.
+func (s *Set) Sub(b SetInput) *Patch {
+	extent, bExtent := s.Extent(), b.Extent()
 	// Deal with nil extent. See https://github.com/google/go-intervals/issues/6.
-	if extent == nil {
-		// Set is already empty, no changes necessary.
-		return
+	if extent == nil || bExtent == nil {
+		// One of the sets is empty, no changes necessary.
+		return &Patch{}
 	}
+	old := s.intervals
+	s.sub(b, extent)
+	return s.changed(old, bExtent)
+}
+
+// sub subtracts b from the set, which must have the given non-nil extent.
+func (s *Set) sub(b SetInput, extent Interval) {
 	var newIntervals []Interval
 	push := func(x Interval) {
 		newIntervals = adjoinOrAppend(newIntervals, x)
 	}
 	nextX := s.iterator(extent, true)
-	nextY, cancel := setIntervalIterator(b, extent)
-	defer cancel()
+	var nextY func() Interval
+	switch b := b.(type) {
+	case *Set:
+		// Avoid the goroutine needed to iterate over an arbitrary SetInput.
+		nextY = b.iterator(extent, true)
+	case *ImmutableSet:
+		nextY = b.set.iterator(extent, true)
+	default:
+		var cancel func()
+		nextY, cancel = setIntervalIterator(b, extent)
+		defer cancel()
+	}
 
 	x := nextX()
 	y := nextY()
@@ -355,8 +461,16 @@ func (s *Set) intersectionIterator(b SetInput) (iter func() Interval, cancel fun
 	})
 }
 
-// Intersect destructively modifies the set by intersectin it with b.
-func (s *Set) Intersect(b SetInput) {
+// Intersect destructively modifies the set by intersectin it with b. It
+// returns a patch describing the intervals that were actually removed.
+func (s *Set) Intersect(b SetInput) *Patch {
+	old := s.intervals
+	s.intersect(b)
+	return s.changed(old, nil)
+}
+
+// intersect replaces the intervals of the set with its intersection with b.
+func (s *Set) intersect(b SetInput) {
 	iter, cancel := s.intersectionIterator(b)
 	defer cancel()
 	var newIntervals []Interval
@@ -51,21 +51,25 @@ func (s *ImmutableSet) Contains(ival Interval) bool {
 // Union returns a set with the contents of this set and another set.
 func (s *ImmutableSet) Union(b SetInput) *ImmutableSet {
 	union := s.set.Copy()
-	union.Add(b)
+	if bExtent := b.Extent(); bExtent != nil {
+		union.add(b, bExtent)
+	}
 	return &ImmutableSet{union}
 }
 
 // Sub returns a set without the intervals of another set.
 func (s *ImmutableSet) Sub(b SetInput) *ImmutableSet {
 	x := s.set.Copy()
-	x.Sub(b)
+	if extent := x.Extent(); extent != nil && b.Extent() != nil {
+		x.sub(b, extent)
+	}
 	return &ImmutableSet{x}
 }
 
 // Intersect returns the intersection of two sets.
 func (s *ImmutableSet) Intersect(b SetInput) *ImmutableSet {
 	x := s.set.Copy()
-	x.Intersect(b)
+	x.intersect(b)
 	return &ImmutableSet{x}
 }
 
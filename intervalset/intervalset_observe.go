@@ -0,0 +1,84 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package intervalset
+
+// observer wraps an observer function so that it can be removed from a set by
+// identity.
+type observer struct {
+	f func(*Patch)
+}
+
+// Observe registers f to be called synchronously after each mutation that
+// changes the set. f receives a patch whose Added intervals were not in the set
+// before the mutation and whose Removed intervals are no longer in the set.
+// Observers are called in the order they were registered.
+//
+// f must not mutate the set. The returned function unregisters f.
+func (s *Set) Observe(f func(*Patch)) (cancel func()) {
+	o := &observer{f}
+	s.observers = append(s.observers, o)
+	return func() {
+		for i, x := range s.observers {
+			if x == o {
+				s.observers = append(s.observers[:i:i], s.observers[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// changed returns a patch describing how the set changed from the old slice of
+// intervals, and notifies observers if the patch is not empty. If extent is not
+// nil, only the portions of the set within extent are compared.
+//
+// The caller must replace s.intervals with a new slice rather than modifying
+// old in place.
+func (s *Set) changed(old []Interval, extent Interval) *Patch {
+	before := &Set{intervals: old, factory: s.factory}
+	after := &Set{intervals: s.intervals, factory: s.factory}
+	if extent != nil {
+		before, after = before.between(extent), after.between(extent)
+	}
+	return s.report(NewPatch(before, after))
+}
+
+// report records p and notifies observers if p is not empty, and returns p.
+func (s *Set) report(p *Patch) *Patch {
+	if !p.IsEmpty() {
+		s.record(p)
+	}
+	s.notify(p)
+	return p
+}
+
+// notify calls each observer with p if p is not empty.
+func (s *Set) notify(p *Patch) {
+	if p.IsEmpty() {
+		return
+	}
+	for _, o := range append([]*observer(nil), s.observers...) {
+		o.f(p)
+	}
+}
+
+// between returns a set containing the portion of s within extents.
+func (s *Set) between(extents Interval) *Set {
+	result := &Set{factory: s.factory}
+	s.IntervalsBetween(extents, func(x Interval) bool {
+		result.intervals = append(result.intervals, x)
+		return true
+	})
+	return result
+}
@@ -0,0 +1,130 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package intervalset
+
+// Diff returns the intervals that are in newSet but not oldSet, and the
+// intervals that are in oldSet but not newSet.
+func Diff(oldSet, newSet SetInput) (added, removed *Set) {
+	return difference(newSet, oldSet), difference(oldSet, newSet)
+}
+
+// difference returns a new set containing a - b. Unlike Add and Sub, it does
+// not compute a patch.
+func difference(a, b SetInput) *Set {
+	result := emptyLike(a)
+	if extent := a.Extent(); extent != nil {
+		var intervals []Interval
+		a.IntervalsBetween(extent, func(x Interval) bool {
+			intervals = append(intervals, x)
+			return true
+		})
+		result.intervals = normalize(intervals)
+	}
+	if extent := result.Extent(); extent != nil && b.Extent() != nil {
+		result.sub(b, extent)
+	}
+	return result
+}
+
+// emptyLike returns an empty set that constructs zero intervals the same way
+// as x, if x is a Set or ImmutableSet.
+func emptyLike(x SetInput) *Set {
+	switch x := x.(type) {
+	case *Set:
+		return &Set{factory: x.factory}
+	case *ImmutableSet:
+		return &Set{factory: x.set.factory}
+	}
+	return Empty()
+}
+
+// Patch is a set of edits that can be applied to a Set: Removed is subtracted
+// from the set, then Added is added to it.
+//
+// The fields of Patch are exported so that patches can be serialized. The
+// intervals in each slice may be in any order and may overlap; they are
+// normalized when the patch is applied. Serializing a patch with encoding/gob
+// requires registering the concrete Interval type with gob.Register.
+type Patch struct {
+	Added   []Interval
+	Removed []Interval
+}
+
+// NewPatch returns a patch that transforms oldSet into newSet.
+func NewPatch(oldSet, newSet SetInput) *Patch {
+	added, removed := Diff(oldSet, newSet)
+	return &Patch{added.intervals, removed.intervals}
+}
+
+// IsEmpty reports whether applying the patch has no effect on any set.
+func (p *Patch) IsEmpty() bool {
+	return len(p.Added) == 0 && len(p.Removed) == 0
+}
+
+// Invert returns a patch that reverts p. It only reverts p exactly if p
+// describes an actual change to a set, as the patches returned by the mutating
+// methods of Set do: every added interval must not have been in the set, and
+// every removed interval must have been in it.
+func (p *Patch) Invert() *Patch {
+	return &Patch{Added: p.Removed, Removed: p.Added}
+}
+
+// Apply destructively modifies s by applying the patch to it. Applying the
+// patch is a single change: observers are notified once, and it is a single
+// edit in the set's history.
+func (p *Patch) Apply(s *Set) {
+	removed, added := patchSet(p.Removed), patchSet(p.Added)
+	removedExtent, addedExtent := removed.Extent(), added.Extent()
+	var extent Interval
+	switch {
+	case len(removed.intervals) == 0 && len(added.intervals) == 0:
+		return
+	case len(removed.intervals) == 0:
+		extent = addedExtent
+	case len(added.intervals) == 0:
+		extent = removedExtent
+	default:
+		extent = removedExtent.Encompass(addedExtent)
+	}
+	// insert modifies s.intervals in place, so the old intervals are copied.
+	old := append([]Interval(nil), s.intervals...)
+	if len(s.intervals) > 0 && len(removed.intervals) > 0 {
+		s.sub(removed, s.Extent())
+	}
+	if len(added.intervals) > 0 {
+		s.add(added, addedExtent)
+	}
+	s.changed(old, extent)
+}
+
+// Then returns a patch that is equivalent to applying p followed by next.
+func (p *Patch) Then(next *Patch) *Patch {
+	removed := patchSet(append(append([]Interval(nil), p.Removed...), next.Removed...))
+	added := difference(patchSet(p.Added), patchSet(next.Removed))
+	added = patchSet(append(added.intervals, next.Added...))
+	return &Patch{added.intervals, removed.intervals}
+}
+
+// patchSet returns a set containing the union of intervals, which may be in
+// any order.
+func patchSet(intervals []Interval) *Set {
+	var nonZero []Interval
+	for _, x := range intervals {
+		if x != nil && !x.IsZero() {
+			nonZero = append(nonZero, x)
+		}
+	}
+	return &Set{intervals: normalize(nonZero), factory: oldBehaviorFactory}
+}
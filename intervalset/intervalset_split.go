@@ -0,0 +1,154 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package intervalset
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Splitter is an optional interface for Interval implementations that can be
+// cut at a point. It is required by Set.SplitAt, Set.TrimBefore and
+// Set.TrimAfter.
+type Splitter interface {
+	// Split returns the portion of the interval before point and the portion
+	// at or after point. Either return value may be the zero interval. The type
+	// of point depends on the implementation.
+	Split(point interface{}) (Interval, Interval)
+}
+
+func splitterOrPanic(x Interval) Splitter {
+	s, ok := x.(Splitter)
+	if !ok {
+		panic(fmt.Errorf("interval does not implement intervalset.Splitter: %v", x))
+	}
+	return s
+}
+
+// SplitAt cuts the set at each of the given points, which must be in
+// increasing order, and returns len(points)+1 sets. The first set contains the
+// portion of s before points[0], the set at index i contains the portion of s
+// in [points[i-1], points[i]), and the last set contains the portion at or
+// after the last point. Some of the returned sets may be empty.
+//
+// Every interval in the set must implement Splitter, since Split is what
+// tells which side of a point an interval lies on. The set itself is not
+// modified.
+func (s *Set) SplitAt(points ...interface{}) []*Set {
+	result := make([]*Set, 0, len(points)+1)
+	i := 0
+	// carry is the remaining portion of an interval that straddled the
+	// previous point.
+	var carry Interval
+	for _, p := range points {
+		var below []Interval
+		for carry != nil || i < len(s.intervals) {
+			x := carry
+			if x == nil {
+				x = s.intervals[i]
+				i++
+			}
+			carry = nil
+			left, right := splitterOrPanic(x).Split(p)
+			if !left.IsZero() {
+				below = append(below, left)
+			}
+			if !right.IsZero() {
+				carry = right
+				break
+			}
+		}
+		result = append(result, &Set{intervals: below, factory: s.factory})
+	}
+	var rest []Interval
+	if carry != nil {
+		rest = append(rest, carry)
+	}
+	rest = append(rest, s.intervals[i:]...)
+	return append(result, &Set{intervals: rest, factory: s.factory})
+}
+
+// TrimBefore removes the portion of the set before point. It returns a patch
+// describing the intervals that were removed.
+//
+// The intervals of the set must implement Splitter. The cut is found by binary
+// search, so TrimBefore takes O(log n) time plus time proportional to the
+// number of intervals removed.
+func (s *Set) TrimBefore(point interface{}) *Patch {
+	// i is the index of the first interval with a portion at or after point.
+	i := sort.Search(len(s.intervals), func(i int) bool {
+		_, right := splitterOrPanic(s.intervals[i]).Split(point)
+		return !right.IsZero()
+	})
+	if i == len(s.intervals) {
+		p := &Patch{Removed: s.intervals}
+		s.intervals = nil
+		return s.report(p)
+	}
+	// The removed intervals keep the front of the old slice, which the set
+	// never writes to again.
+	removed := s.intervals[:i:i]
+	left, right := splitterOrPanic(s.intervals[i]).Split(point)
+	if !left.IsZero() {
+		removed = append(removed, left)
+	}
+	s.intervals = s.intervals[i:]
+	s.intervals[0] = right
+	return s.report(&Patch{Removed: removed})
+}
+
+// TrimAfter removes the portion of the set at or after point. It returns a
+// patch describing the intervals that were removed.
+//
+// The intervals of the set must implement Splitter. The cut is found by binary
+// search, so TrimAfter takes O(log n) time plus time proportional to the
+// number of intervals removed.
+func (s *Set) TrimAfter(point interface{}) *Patch {
+	// j is the index of the first interval with no portion before point.
+	j := sort.Search(len(s.intervals), func(i int) bool {
+		left, _ := splitterOrPanic(s.intervals[i]).Split(point)
+		return left.IsZero()
+	})
+	removed := s.intervals[j:]
+	// The capacity of the kept slice is limited so that appending to it does
+	// not overwrite the removed intervals.
+	kept := s.intervals[:j:j]
+	if j > 0 {
+		left, right := splitterOrPanic(kept[j-1]).Split(point)
+		if !right.IsZero() {
+			removed = append([]Interval{right}, removed...)
+			kept[j-1] = left
+		}
+	}
+	s.intervals = kept
+	return s.report(&Patch{Removed: removed})
+}
+
+// Partition groups the intervals of the set by the value returned by key and
+// returns a set for each distinct key. The values returned by key must be
+// comparable. The set itself is not modified.
+func (s *Set) Partition(key func(Interval) interface{}) map[interface{}]*Set {
+	result := make(map[interface{}]*Set)
+	for _, x := range s.intervals {
+		k := key(x)
+		part, ok := result[k]
+		if !ok {
+			part = &Set{factory: s.factory}
+			result[k] = part
+		}
+		part.intervals = append(part.intervals, x)
+	}
+	return result
+}
@@ -126,6 +126,48 @@ func (s *span) Encompass(tInt Interval) Interval {
 	return &span{min(s.min, t.min), max(s.max, t.max)}
 }
 
+// spanOrZero returns the span between a and b, swapping them if b < a.
+func spanOrZero(a, b int) *span {
+	if a == b {
+		return zero()
+	}
+	if b < a {
+		a, b = b, a
+	}
+	return &span{a, b}
+}
+
+// MapEndpoints returns the interval [f(min), f(max)).
+func (s *span) MapEndpoints(f func(interface{}) interface{}) Interval {
+	return spanOrZero(f(s.min).(int), f(s.max).(int))
+}
+
+// Translate returns the interval shifted by offset.
+func (s *span) Translate(offset interface{}) Interval {
+	return &span{s.min + offset.(int), s.max + offset.(int)}
+}
+
+// Scale returns the interval scaled by factor about origin.
+func (s *span) Scale(origin interface{}, factor float64) Interval {
+	o := origin.(int)
+	scale := func(x int) int {
+		return o + int(float64(x-o)*factor)
+	}
+	return spanOrZero(scale(s.min), scale(s.max))
+}
+
+// Split returns the portions of the interval before and at or after point.
+func (s *span) Split(point interface{}) (Interval, Interval) {
+	p := point.(int)
+	if p <= s.min {
+		return zero(), s
+	}
+	if p >= s.max {
+		return s, zero()
+	}
+	return &span{s.min, p}, &span{p, s.max}
+}
+
 func TestExtent(t *testing.T) {
 	x := &span{20, 40}
 	y := &span{60, 100}
@@ -428,3 +470,438 @@ func TestContains(t *testing.T) {
 		}
 	}
 }
+
+func TestTransform(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		a         *Set
+		transform func(*Set)
+		want      []*span
+	}{
+		{
+			name:      "translate empty",
+			a:         NewSet([]Interval{}),
+			transform: func(s *Set) { s.Translate(5) },
+			want:      []*span{},
+		},
+		{
+			name:      "translate [0, 2) [4, 6) by 5",
+			a:         NewSet([]Interval{&span{0, 2}, &span{4, 6}}),
+			transform: func(s *Set) { s.Translate(5) },
+			want:      []*span{{5, 7}, {9, 11}},
+		},
+		{
+			name:      "scale [1, 2) [4, 6) by 2 about 1",
+			a:         NewSet([]Interval{&span{1, 2}, &span{4, 6}}),
+			transform: func(s *Set) { s.Scale(1, 2) },
+			want:      []*span{{1, 3}, {7, 11}},
+		},
+		{
+			name:      "scale [1, 2) [4, 6) by -1 about 10 reverses order",
+			a:         NewSet([]Interval{&span{1, 2}, &span{4, 6}}),
+			transform: func(s *Set) { s.Scale(10, -1) },
+			want:      []*span{{14, 16}, {18, 19}},
+		},
+		{
+			name:      "scale by 0 empties the set",
+			a:         NewSet([]Interval{&span{1, 2}, &span{4, 6}}),
+			transform: func(s *Set) { s.Scale(3, 0) },
+			want:      []*span{},
+		},
+		{
+			name: "monotone map coalesces adjoining intervals",
+			a:    NewSet([]Interval{&span{0, 2}, &span{4, 6}}),
+			transform: func(s *Set) {
+				s.MapEndpoints(func(x interface{}) interface{} {
+					if x.(int) == 4 {
+						return 2
+					}
+					return x
+				})
+			},
+			want: []*span{{0, 6}},
+		},
+		{
+			name: "non-monotone map re-sorts and merges",
+			a:    NewSet([]Interval{&span{0, 2}, &span{4, 6}, &span{8, 10}}),
+			transform: func(s *Set) {
+				s.MapEndpoints(func(x interface{}) interface{} {
+					// Swap the first and last intervals, overlapping the middle one.
+					switch x.(int) {
+					case 0:
+						return 9
+					case 2:
+						return 11
+					case 8:
+						return 1
+					case 10:
+						return 5
+					}
+					return x
+				})
+			},
+			want: []*span{{1, 6}, {9, 11}},
+		},
+		{
+			name: "non-monotone map merges nested and adjoining intervals",
+			a:    NewSet([]Interval{&span{0, 2}, &span{4, 6}, &span{8, 10}, &span{12, 14}, &span{16, 18}}),
+			transform: func(s *Set) {
+				to := map[int]int{0: 5, 2: 8, 4: 0, 6: 3, 8: 3, 10: 4, 12: 20, 14: 21, 16: 6, 18: 7}
+				s.MapEndpoints(func(x interface{}) interface{} {
+					return to[x.(int)]
+				})
+			},
+			want: []*span{{0, 4}, {5, 8}, {20, 21}},
+		},
+	} {
+		tt.transform(tt.a)
+		if got := allIntervals(tt.a); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSplitAt(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		a      *Set
+		points []interface{}
+		want   [][]*span
+	}{
+		{
+			name:   "empty split at 5",
+			a:      NewSet([]Interval{}),
+			points: []interface{}{5},
+			want:   [][]*span{{}, {}},
+		},
+		{
+			name:   "no points",
+			a:      NewSet([]Interval{&span{0, 2}, &span{4, 6}}),
+			points: nil,
+			want:   [][]*span{{{0, 2}, {4, 6}}},
+		},
+		{
+			name:   "[0, 2) [4, 10) split at 1, 5, 8",
+			a:      NewSet([]Interval{&span{0, 2}, &span{4, 10}}),
+			points: []interface{}{1, 5, 8},
+			want:   [][]*span{{{0, 1}}, {{1, 2}, {4, 5}}, {{5, 8}}, {{8, 10}}},
+		},
+		{
+			name:   "[4, 6) split at boundaries and outside",
+			a:      NewSet([]Interval{&span{4, 6}}),
+			points: []interface{}{1, 4, 6, 20},
+			want:   [][]*span{{}, {}, {{4, 6}}, {}, {}},
+		},
+	} {
+		var got [][]*span
+		for _, part := range tt.a.SplitAt(tt.points...) {
+			got = append(got, part.intervalsSlice())
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestTrim(t *testing.T) {
+	spans := func(intervals []Interval) []*span {
+		result := []*span{}
+		for _, x := range intervals {
+			result = append(result, cast(x))
+		}
+		return result
+	}
+	newSet := func() *Set {
+		return NewSet([]Interval{&span{0, 2}, &span{4, 10}, &span{12, 14}})
+	}
+	for _, tt := range []struct {
+		name        string
+		before      bool
+		point       int
+		want, trims []*span
+	}{
+		{"before 5", true, 5, []*span{{5, 10}, {12, 14}}, []*span{{0, 2}, {4, 5}}},
+		{"before 4", true, 4, []*span{{4, 10}, {12, 14}}, []*span{{0, 2}}},
+		{"before -1", true, -1, []*span{{0, 2}, {4, 10}, {12, 14}}, []*span{}},
+		{"before 20", true, 20, []*span{}, []*span{{0, 2}, {4, 10}, {12, 14}}},
+		{"after 5", false, 5, []*span{{0, 2}, {4, 5}}, []*span{{5, 10}, {12, 14}}},
+		{"after 10", false, 10, []*span{{0, 2}, {4, 10}}, []*span{{12, 14}}},
+		{"after 0", false, 0, []*span{}, []*span{{0, 2}, {4, 10}, {12, 14}}},
+		{"after 20", false, 20, []*span{{0, 2}, {4, 10}, {12, 14}}, []*span{}},
+	} {
+		set := newSet()
+		var p *Patch
+		if tt.before {
+			p = set.TrimBefore(tt.point)
+		} else {
+			p = set.TrimAfter(tt.point)
+		}
+		if got := set.intervalsSlice(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+		if got := spans(p.Removed); !reflect.DeepEqual(got, tt.trims) || len(p.Added) != 0 {
+			t.Errorf("%s: patch removed %v and added %v, want removed %v", tt.name, got, p.Added, tt.trims)
+		}
+		// Later mutations must not disturb the returned patch.
+		set.Add(NewSet([]Interval{&span{-5, 30}}))
+		if got := spans(p.Removed); !reflect.DeepEqual(got, tt.trims) {
+			t.Errorf("%s: patch changed to %v after a later Add", tt.name, got)
+		}
+	}
+}
+
+func TestPartition(t *testing.T) {
+	a := NewSet([]Interval{&span{1, 2}, &span{4, 6}, &span{11, 12}, &span{15, 20}})
+	got := map[interface{}][]*span{}
+	for k, part := range a.Partition(func(x Interval) interface{} { return cast(x).min / 10 }) {
+		got[k] = part.intervalsSlice()
+	}
+	want := map[interface{}][]*span{
+		0: {{1, 2}, {4, 6}},
+		1: {{11, 12}, {15, 20}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Partition() = %v, want %v", got, want)
+	}
+}
+
+// intervalsSlice returns the intervals of the set as a slice of *span.
+func (s *Set) intervalsSlice() []*span {
+	result := []*span{}
+	s.Intervals(func(x Interval) bool {
+		result = append(result, cast(x))
+		return true
+	})
+	return result
+}
+
+func TestDiffAndPatch(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		old, new    *Set
+		wantAdded   []*span
+		wantRemoved []*span
+	}{
+		{
+			name:        "empty to empty",
+			old:         NewSet([]Interval{}),
+			new:         NewSet([]Interval{}),
+			wantAdded:   []*span{},
+			wantRemoved: []*span{},
+		},
+		{
+			name:        "empty to [0, 5)",
+			old:         NewSet([]Interval{}),
+			new:         NewSet([]Interval{&span{0, 5}}),
+			wantAdded:   []*span{{0, 5}},
+			wantRemoved: []*span{},
+		},
+		{
+			name:        "[0, 5) [10, 15) to [3, 12)",
+			old:         NewSet([]Interval{&span{0, 5}, &span{10, 15}}),
+			new:         NewSet([]Interval{&span{3, 12}}),
+			wantAdded:   []*span{{5, 10}},
+			wantRemoved: []*span{{0, 3}, {12, 15}},
+		},
+	} {
+		added, removed := Diff(tt.old, tt.new)
+		if got := allIntervals(added); !reflect.DeepEqual(got, tt.wantAdded) {
+			t.Errorf("%s: added = %v, want %v", tt.name, got, tt.wantAdded)
+		}
+		if got := allIntervals(removed); !reflect.DeepEqual(got, tt.wantRemoved) {
+			t.Errorf("%s: removed = %v, want %v", tt.name, got, tt.wantRemoved)
+		}
+		patched := tt.old.Copy()
+		NewPatch(tt.old, tt.new).Apply(patched)
+		if got, want := allIntervals(patched), allIntervals(tt.new); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: patched old = %v, want %v", tt.name, got, want)
+		}
+	}
+}
+
+func TestPatchThen(t *testing.T) {
+	a := NewSet([]Interval{&span{0, 5}, &span{10, 15}})
+	b := NewSet([]Interval{&span{3, 12}})
+	c := NewSet([]Interval{&span{1, 4}, &span{11, 20}})
+
+	composed := NewPatch(a, b).Then(NewPatch(b, c))
+	got := a.Copy()
+	composed.Apply(got)
+	if got, want := allIntervals(got), allIntervals(c); !reflect.DeepEqual(got, want) {
+		t.Errorf("a patched with a->b then b->c = %v, want %v", got, want)
+	}
+}
+
+func TestPatchApplyIsOneEdit(t *testing.T) {
+	set := NewSet([]Interval{&span{0, 5}, &span{10, 15}})
+	set.EnableHistory(5)
+	var observed []*Patch
+	set.Observe(func(p *Patch) {
+		observed = append(observed, p)
+	})
+
+	(&Patch{Added: []Interval{&span{20, 25}}, Removed: []Interval{&span{3, 12}}}).Apply(set)
+	if got, want := allIntervals(set), []*span{{0, 3}, {12, 15}, {20, 25}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("set after Apply() = %v, want %v", got, want)
+	}
+	if len(observed) != 1 {
+		t.Fatalf("observers were notified %d times, want once", len(observed))
+	}
+	if got, want := allIntervals(patchSet(observed[0].Added)), []*span{{20, 25}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("observed Added = %v, want %v", got, want)
+	}
+	if got, want := allIntervals(patchSet(observed[0].Removed)), []*span{{3, 5}, {10, 12}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("observed Removed = %v, want %v", got, want)
+	}
+
+	if !set.Undo() {
+		t.Fatalf("Undo() = false, want true")
+	}
+	if got, want := allIntervals(set), []*span{{0, 5}, {10, 15}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("set after Undo() = %v, want %v", got, want)
+	}
+	if set.Undo() {
+		t.Errorf("Undo() = true, want a single edit for Apply")
+	}
+}
+
+func TestMutationPatches(t *testing.T) {
+	type patchSpans struct {
+		added, removed []*span
+	}
+	toSpans := func(p *Patch) patchSpans {
+		result := patchSpans{[]*span{}, []*span{}}
+		for _, x := range p.Added {
+			result.added = append(result.added, cast(x))
+		}
+		for _, x := range p.Removed {
+			result.removed = append(result.removed, cast(x))
+		}
+		return result
+	}
+
+	set := NewSet([]Interval{&span{0, 5}, &span{10, 15}})
+	var observed []patchSpans
+	cancel := set.Observe(func(p *Patch) {
+		observed = append(observed, toSpans(p))
+	})
+
+	var returned []patchSpans
+	returned = append(returned, toSpans(set.Add(NewSet([]Interval{&span{3, 12}}))))
+	returned = append(returned, toSpans(set.Add(NewSet([]Interval{&span{1, 2}}))))
+	returned = append(returned, toSpans(set.Sub(NewSet([]Interval{&span{4, 6}, &span{14, 20}}))))
+	returned = append(returned, toSpans(set.Intersect(NewSet([]Interval{&span{1, 12}}))))
+	cancel()
+	set.Add(NewSet([]Interval{&span{30, 40}}))
+
+	want := []patchSpans{
+		{added: []*span{{5, 10}}, removed: []*span{}},
+		{added: []*span{}, removed: []*span{}},
+		{added: []*span{}, removed: []*span{{4, 6}, {14, 15}}},
+		{added: []*span{}, removed: []*span{{0, 1}, {12, 14}}},
+	}
+	if !reflect.DeepEqual(returned, want) {
+		t.Errorf("returned patches = %v, want %v", returned, want)
+	}
+	// The observer is not called for the no-op Add, or after it is cancelled.
+	wantObserved := []patchSpans{want[0], want[2], want[3]}
+	if !reflect.DeepEqual(observed, wantObserved) {
+		t.Errorf("observed patches = %v, want %v", observed, wantObserved)
+	}
+}
+
+func TestTransaction(t *testing.T) {
+	set := NewSet([]Interval{&span{0, 5}, &span{10, 15}})
+	want := allIntervals(set)
+
+	tx := set.Begin()
+	tx.Add(NewSet([]Interval{&span{3, 12}}))
+	tx.Sub(NewSet([]Interval{&span{1, 2}}))
+	set.Intersect(NewSet([]Interval{&span{0, 13}}))
+	if got, want := allIntervals(set), []*span{{0, 1}, {2, 13}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("set during transaction = %v, want %v", got, want)
+	}
+	tx.Rollback()
+	if got := allIntervals(set); !reflect.DeepEqual(got, want) {
+		t.Errorf("set after Rollback() = %v, want %v", got, want)
+	}
+
+	tx = set.Begin()
+	tx.Add(NewSet([]Interval{&span{3, 12}}))
+	p := tx.Patch()
+	tx.Commit()
+	if got, want := allIntervals(set), []*span{{0, 15}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("set after Commit() = %v, want %v", got, want)
+	}
+	if got, want := allIntervals(patchSet(p.Added)), []*span{{5, 10}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("tx.Patch().Added = %v, want %v", got, want)
+	}
+}
+
+func TestUndoCommittedTransaction(t *testing.T) {
+	set := NewSet([]Interval{})
+	set.EnableHistory(5)
+	tx := set.Begin()
+	tx.Add(NewSet([]Interval{&span{0, 10}}))
+	tx.Sub(NewSet([]Interval{&span{0, 5}}))
+	p := tx.Patch()
+	tx.Commit()
+	if got, want := allIntervals(set), []*span{{5, 10}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("set after Commit() = %v, want %v", got, want)
+	}
+	// [0, 5) was never in the set outside the transaction.
+	if len(p.Removed) != 0 {
+		t.Errorf("tx.Patch().Removed = %v, want none", p.Removed)
+	}
+	if !set.Undo() {
+		t.Fatalf("Undo() = false, want true")
+	}
+	if got := allIntervals(set); len(got) != 0 {
+		t.Errorf("set after Undo() = %v, want {}", got)
+	}
+	if !set.Redo() {
+		t.Fatalf("Redo() = false, want true")
+	}
+	if got, want := allIntervals(set), []*span{{5, 10}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("set after Redo() = %v, want %v", got, want)
+	}
+}
+
+func TestUndoRedo(t *testing.T) {
+	set := NewSet([]Interval{&span{0, 5}})
+	set.EnableHistory(2)
+
+	set.Add(NewSet([]Interval{&span{10, 15}}))
+	tx := set.Begin()
+	tx.Add(NewSet([]Interval{&span{20, 25}}))
+	tx.Sub(NewSet([]Interval{&span{0, 1}}))
+	tx.Commit()
+	set.Sub(NewSet([]Interval{&span{12, 13}}))
+
+	for i, want := range [][]*span{
+		{{1, 5}, {10, 15}, {20, 25}},
+		{{0, 5}, {10, 15}},
+	} {
+		if !set.Undo() {
+			t.Fatalf("Undo() #%d = false, want true", i)
+		}
+		if got := allIntervals(set); !reflect.DeepEqual(got, want) {
+			t.Errorf("set after Undo() #%d = %v, want %v", i, got, want)
+		}
+	}
+	// Only two edits are kept.
+	if set.Undo() {
+		t.Errorf("Undo() = true after the history limit was reached, want false")
+	}
+
+	if !set.Redo() {
+		t.Fatalf("Redo() = false, want true")
+	}
+	if got, want := allIntervals(set), []*span{{1, 5}, {10, 15}, {20, 25}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("set after Redo() = %v, want %v", got, want)
+	}
+	set.Add(NewSet([]Interval{&span{30, 35}}))
+	if set.Redo() {
+		t.Errorf("Redo() = true after a new edit, want false")
+	}
+}
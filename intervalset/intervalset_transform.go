@@ -0,0 +1,147 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package intervalset
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Transformer is an optional interface for Interval implementations whose
+// endpoints can be mapped to new values. It is required by Set.MapEndpoints,
+// Set.Translate and Set.Scale.
+//
+// Each method returns a new interval and must not modify the receiver. If the
+// mapped endpoints are in decreasing order, they are swapped. If the mapped
+// endpoints are equal, the zero interval is returned.
+type Transformer interface {
+	// MapEndpoints returns the interval [f(start), f(end)).
+	MapEndpoints(f func(endpoint interface{}) interface{}) Interval
+
+	// Translate returns the interval shifted by offset. The type of offset
+	// depends on the implementation.
+	Translate(offset interface{}) Interval
+
+	// Scale returns the interval with each endpoint x replaced by
+	// origin + (x - origin) * factor. The type of origin depends on the
+	// implementation.
+	Scale(origin interface{}, factor float64) Interval
+}
+
+func transformerOrPanic(x Interval) Transformer {
+	t, ok := x.(Transformer)
+	if !ok {
+		panic(fmt.Errorf("interval does not implement intervalset.Transformer: %v", x))
+	}
+	return t
+}
+
+// MapEndpoints destructively modifies the set by applying f to the endpoints
+// of each of its intervals. Every interval in the set must implement
+// Transformer.
+//
+// If f is monotonically non-decreasing, the intervals remain sorted and are
+// coalesced in a single pass. Otherwise the intervals are re-sorted and merged
+// in O(n log n) time.
+func (s *Set) MapEndpoints(f func(endpoint interface{}) interface{}) *Patch {
+	return s.transform(func(x Transformer) Interval {
+		return x.MapEndpoints(f)
+	})
+}
+
+// Translate destructively modifies the set by shifting each of its intervals by
+// offset. Every interval in the set must implement Transformer.
+func (s *Set) Translate(offset interface{}) *Patch {
+	return s.transform(func(x Transformer) Interval {
+		return x.Translate(offset)
+	})
+}
+
+// Scale destructively modifies the set by scaling each of its intervals about
+// origin. A negative factor reverses the order of the intervals, and a factor of
+// zero empties the set. Every interval in the set must implement Transformer.
+func (s *Set) Scale(origin interface{}, factor float64) *Patch {
+	return s.transform(func(x Transformer) Interval {
+		return x.Scale(origin, factor)
+	})
+}
+
+func (s *Set) transform(fn func(Transformer) Interval) *Patch {
+	old := s.intervals
+	mapped := make([]Interval, 0, len(s.intervals))
+	for _, x := range s.intervals {
+		y := fn(transformerOrPanic(x))
+		if y == nil || y.IsZero() {
+			continue
+		}
+		mapped = append(mapped, y)
+	}
+	s.intervals = normalize(mapped)
+	return s.changed(old, nil)
+}
+
+// normalize returns a sorted, non-overlapping and non-adjoining slice
+// containing exactly the points covered by intervals, which may be in any
+// order.
+func normalize(intervals []Interval) []Interval {
+	if result, ok := coalesceSorted(intervals, false); ok {
+		return result
+	}
+	if result, ok := coalesceSorted(intervals, true); ok {
+		return result
+	}
+	// Sort by start. x starts before y if subtracting y from x leaves a
+	// non-zero lower part.
+	sorted := append([]Interval(nil), intervals...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		lower, _ := sorted[i].Bisect(sorted[j])
+		return !lower.IsZero()
+	})
+	var result []Interval
+	var current Interval
+	for _, x := range sorted {
+		switch {
+		case current == nil:
+			current = x
+		case current.Before(x):
+			result = adjoinOrAppend(result, current)
+			current = x
+		default:
+			current = current.Encompass(x)
+		}
+	}
+	if current != nil {
+		result = adjoinOrAppend(result, current)
+	}
+	return result
+}
+
+// coalesceSorted merges adjoining intervals of a slice that is sorted in
+// increasing order, or decreasing order if reverse is true. The second return
+// value is false if the slice is not sorted.
+func coalesceSorted(intervals []Interval, reverse bool) ([]Interval, bool) {
+	var result []Interval
+	for i := range intervals {
+		x := intervals[i]
+		if reverse {
+			x = intervals[len(intervals)-1-i]
+		}
+		if n := len(result); n > 0 && !result[n-1].Before(x) {
+			return nil, false
+		}
+		result = adjoinOrAppend(result, x)
+	}
+	return result, true
+}
@@ -0,0 +1,188 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package intervalset
+
+import "fmt"
+
+// Tx is a transaction on a Set, created by Set.Begin. While a transaction is
+// open, every change made to the set is recorded as a patch so that the changes
+// can be rolled back. No copy of the set is made.
+type Tx struct {
+	set *Set
+	// log contains the patches describing each change made to the set during
+	// the transaction, in order.
+	log  []*Patch
+	done bool
+}
+
+// Begin starts a transaction on the set. Every mutation of the set, whether
+// made through the Tx or directly on the set, is part of the transaction until
+// Commit or Rollback is called.
+//
+// Begin panics if a transaction is already open on the set.
+func (s *Set) Begin() *Tx {
+	if s.tx != nil {
+		panic(fmt.Errorf("a transaction is already open on the set"))
+	}
+	s.tx = &Tx{set: s}
+	return s.tx
+}
+
+func (tx *Tx) checkOpen() {
+	if tx.done {
+		panic(fmt.Errorf("transaction has already been committed or rolled back"))
+	}
+}
+
+// Add adds all the elements of b to the set. See Set.Add.
+func (tx *Tx) Add(b SetInput) *Patch {
+	tx.checkOpen()
+	return tx.set.Add(b)
+}
+
+// Sub subtracts b from the set. See Set.Sub.
+func (tx *Tx) Sub(b SetInput) *Patch {
+	tx.checkOpen()
+	return tx.set.Sub(b)
+}
+
+// Intersect intersects the set with b. See Set.Intersect.
+func (tx *Tx) Intersect(b SetInput) *Patch {
+	tx.checkOpen()
+	return tx.set.Intersect(b)
+}
+
+// Patch returns a patch describing all the changes made during the
+// transaction so far. Like the patches returned by the mutating methods of
+// Set, it describes an actual change, so its inverse reverts the transaction.
+func (tx *Tx) Patch() *Patch {
+	// Composing the log with Then is not enough: an interval added and then
+	// removed during the transaction would appear as removed. Instead, the set
+	// as it was at Begin is reconstructed by reverting each change in reverse
+	// order, which is exact, and compared with the set now.
+	before := tx.set.Copy()
+	for i := len(tx.log) - 1; i >= 0; i-- {
+		tx.log[i].Invert().Apply(before)
+	}
+	return NewPatch(before, tx.set)
+}
+
+// Commit ends the transaction, keeping its changes. If history is enabled on
+// the set, the changes are recorded as a single undoable edit.
+func (tx *Tx) Commit() {
+	tx.checkOpen()
+	tx.done = true
+	tx.set.tx = nil
+	if p := tx.Patch(); !p.IsEmpty() {
+		tx.set.record(p)
+	}
+}
+
+// Rollback ends the transaction, reverting the set to its state when Begin was
+// called. Observers are notified of the reverting changes.
+func (tx *Tx) Rollback() {
+	tx.checkOpen()
+	tx.done = true
+	tx.set.tx = nil
+	tx.set.replay(func() {
+		for i := len(tx.log) - 1; i >= 0; i-- {
+			tx.log[i].Invert().Apply(tx.set)
+		}
+	})
+}
+
+// history is a bounded undo and redo history of the changes made to a set.
+type history struct {
+	limit      int
+	undo, redo []*Patch
+}
+
+// EnableHistory starts recording the changes made to the set so they can be
+// reverted with Undo and reapplied with Redo. At most limit edits are kept; the
+// oldest are discarded first. A limit of zero or less disables history.
+//
+// Each mutation is one edit, except that a committed transaction is a single
+// edit.
+func (s *Set) EnableHistory(limit int) {
+	if limit <= 0 {
+		s.history = nil
+		return
+	}
+	s.history = &history{limit: limit}
+}
+
+// Undo reverts the most recent edit recorded in the set's history. It reports
+// false if there is nothing to undo. Undo panics if a transaction is open.
+func (s *Set) Undo() bool {
+	return s.undoOrRedo(true)
+}
+
+// Redo reapplies the most recently undone edit. It reports false if there is
+// nothing to redo. Any mutation of the set other than Undo and Redo clears the
+// edits that may be redone. Redo panics if a transaction is open.
+func (s *Set) Redo() bool {
+	return s.undoOrRedo(false)
+}
+
+func (s *Set) undoOrRedo(undo bool) bool {
+	if s.tx != nil {
+		panic(fmt.Errorf("cannot undo or redo while a transaction is open"))
+	}
+	h := s.history
+	if h == nil {
+		return false
+	}
+	from, to := &h.undo, &h.redo
+	if !undo {
+		from, to = to, from
+	}
+	if len(*from) == 0 {
+		return false
+	}
+	p := (*from)[len(*from)-1]
+	*from = (*from)[:len(*from)-1]
+	*to = append(*to, p.Invert())
+	s.replay(func() {
+		p.Invert().Apply(s)
+	})
+	return true
+}
+
+// replay calls f with the recording of changes to history and transactions
+// disabled.
+func (s *Set) replay(f func()) {
+	s.replaying = true
+	defer func() { s.replaying = false }()
+	f()
+}
+
+// record adds a non-empty patch describing a change to the set to the open
+// transaction, or to the history if no transaction is open.
+func (s *Set) record(p *Patch) {
+	if s.replaying {
+		return
+	}
+	if s.tx != nil {
+		s.tx.log = append(s.tx.log, p)
+		return
+	}
+	if h := s.history; h != nil {
+		h.undo = append(h.undo, p)
+		if len(h.undo) > h.limit {
+			h.undo = append([]*Patch(nil), h.undo[len(h.undo)-h.limit:]...)
+		}
+		h.redo = nil
+	}
+}
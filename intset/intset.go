@@ -0,0 +1,279 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package intset is a finite set implementation for ranges of integers.
+//
+// Set holds int64 values and Uint64Set holds uint64 values. Ranges include
+// their lower bound and exclude their upper bound, so the largest value of each
+// type can not be a member of a set.
+//
+// DISCLAIMER: This library is not yet stable, so expect breaking changes.
+package intset
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/google/go-intervals/intervalset"
+	"github.com/google/go-intervals/modinterval"
+)
+
+// Set is a finite set of int64 values. Functions are provided for iterating
+// over the ranges of the set and performing set operations (intersection,
+// union, subtraction).
+//
+// This is an integer-specific implementation of intervalset.Set.
+//
+// A Set is not safe for concurrent use, even by readers only, because Rank and
+// Select cache an index of the set.
+type Set struct {
+	iset *intervalset.Set
+	// index is built by Rank and Select, and discarded by every mutation.
+	index *rankIndex
+}
+
+// rankIndex supports binary searches by value and by rank.
+type rankIndex struct {
+	spans []*span
+	// before[i] is the number of members of the set in spans[:i].
+	before []uint64
+}
+
+func makeZero() intervalset.Interval {
+	return &span{}
+}
+
+// Empty returns a new, empty Set.
+func Empty() *Set {
+	return &Set{iset: intervalset.EmptyV1(makeZero)}
+}
+
+// String returns a human readable version of the set.
+func (s *Set) String() string {
+	return s.iset.String()
+}
+
+// Copy returns a copy of a set that may be mutated without affecting the original.
+func (s *Set) Copy() *Set {
+	return &Set{iset: s.iset.Copy()}
+}
+
+// Insert adds the integers in [lo, hi) to the set. It returns a patch
+// describing the integers that were not already in the set. It panics if
+// hi < lo.
+func (s *Set) Insert(lo, hi int64) *Patch {
+	if hi < lo {
+		panic(fmt.Errorf("hi %d less than lo %d", hi, lo))
+	}
+	if lo == hi {
+		return &Patch{}
+	}
+	s.index = nil
+	return fromIntervalPatch(s.iset.Add(intervalset.NewSetV1([]intervalset.Interval{&span{lo, hi}}, makeZero)))
+}
+
+// Add performs an in-place union of two sets. It returns a patch describing
+// the ranges that were actually added.
+func (s *Set) Add(b *Set) *Patch {
+	s.index = nil
+	return fromIntervalPatch(s.iset.Add(b.iset))
+}
+
+// Sub performs an in-place subtraction of set b from set s. It returns a patch
+// describing the ranges that were actually removed.
+func (s *Set) Sub(b *Set) *Patch {
+	s.index = nil
+	return fromIntervalPatch(s.iset.Sub(b.iset))
+}
+
+// Intersect performs an in-place intersection of sets s and b. It returns a
+// patch describing the ranges that were actually removed.
+func (s *Set) Intersect(b *Set) *Patch {
+	s.index = nil
+	return fromIntervalPatch(s.iset.Intersect(b.iset))
+}
+
+// Extent returns the range [lo, hi) covering the entire set. Both values are
+// zero for an empty set.
+func (s *Set) Extent() (lo, hi int64) {
+	x := spanOrPanic(s.iset.Extent())
+	return x.lo, x.hi
+}
+
+// Empty reports whether the set has no members.
+func (s *Set) Empty() bool {
+	return s.iset.Extent().IsZero()
+}
+
+// Contains reports whether x is a member of the set.
+func (s *Set) Contains(x int64) bool {
+	if x == math.MaxInt64 {
+		return false
+	}
+	return s.iset.Contains(&span{x, x + 1})
+}
+
+// ContainsRange reports whether all of the integers in [lo, hi) are members of
+// the set.
+func (s *Set) ContainsRange(lo, hi int64) bool {
+	if hi <= lo {
+		return true
+	}
+	return s.iset.Contains(&span{lo, hi})
+}
+
+// Cardinality returns the number of integers in the set.
+func (s *Set) Cardinality() uint64 {
+	var n uint64
+	s.iset.Intervals(func(x intervalset.Interval) bool {
+		n += spanOrPanic(x).size()
+		return true
+	})
+	return n
+}
+
+// Min returns the smallest member of the set. The second return value is false
+// if the set is empty.
+func (s *Set) Min() (int64, bool) {
+	if s.Empty() {
+		return 0, false
+	}
+	lo, _ := s.Extent()
+	return lo, true
+}
+
+// Max returns the largest member of the set. The second return value is false
+// if the set is empty.
+func (s *Set) Max() (int64, bool) {
+	if s.Empty() {
+		return 0, false
+	}
+	_, hi := s.Extent()
+	return hi - 1, true
+}
+
+// Select returns the member of the set that has n smaller members, so
+// Select(0) is the smallest member. The second return value is false if
+// n >= s.Cardinality().
+//
+// The first call after a mutation takes O(n) time to index the set; later
+// calls take O(log n) time.
+func (s *Set) Select(n uint64) (int64, bool) {
+	idx := s.indexed()
+	i := sort.Search(len(idx.spans), func(i int) bool {
+		return n < idx.before[i]+idx.spans[i].size()
+	})
+	if i == len(idx.spans) {
+		return 0, false
+	}
+	return idx.spans[i].lo + int64(n-idx.before[i]), true
+}
+
+// Rank returns the number of members of the set that are less than x.
+//
+// The first call after a mutation takes O(n) time to index the set; later
+// calls take O(log n) time.
+func (s *Set) Rank(x int64) uint64 {
+	idx := s.indexed()
+	i := sort.Search(len(idx.spans), func(i int) bool { return idx.spans[i].hi > x })
+	if i == len(idx.spans) {
+		if i == 0 {
+			return 0
+		}
+		return idx.before[i-1] + idx.spans[i-1].size()
+	}
+	n := idx.before[i]
+	if sp := idx.spans[i]; sp.lo < x {
+		n += (&span{sp.lo, x}).size()
+	}
+	return n
+}
+
+// indexed returns the index of the set, building it if necessary.
+func (s *Set) indexed() *rankIndex {
+	if s.index != nil {
+		return s.index
+	}
+	idx := &rankIndex{}
+	var n uint64
+	s.iset.Intervals(func(x intervalset.Interval) bool {
+		sp := spanOrPanic(x)
+		idx.spans = append(idx.spans, sp)
+		idx.before = append(idx.before, n)
+		n += sp.size()
+		return true
+	})
+	s.index = idx
+	return idx
+}
+
+// IntervalReceiver is a function used for iterating over a set of integer
+// ranges. It takes the lower (inclusive) and upper (exclusive) bounds of a
+// range and returns true if the iteration should continue.
+type IntervalReceiver func(lo, hi int64) bool
+
+// IntervalsBetween iterates over the ranges within the set and calls f with the
+// lower (inclusive) and upper (exclusive) bound of each. If f returns false,
+// iteration ceases. Ranges are truncated to [lo, hi).
+func (s *Set) IntervalsBetween(lo, hi int64, f IntervalReceiver) {
+	if hi <= lo {
+		return
+	}
+	s.iset.IntervalsBetween(&span{lo, hi}, func(x intervalset.Interval) bool {
+		sp := spanOrPanic(x)
+		return f(sp.lo, sp.hi)
+	})
+}
+
+// Intervals iterates over all the ranges within the set and calls f with the
+// lower (inclusive) and upper (exclusive) bound of each. If f returns false,
+// iteration ceases.
+func (s *Set) Intervals(f IntervalReceiver) {
+	s.iset.Intervals(func(x intervalset.Interval) bool {
+		sp := spanOrPanic(x)
+		return f(sp.lo, sp.hi)
+	})
+}
+
+// FromRealIntIntervals returns a set containing the union of the given
+// intervals.
+func FromRealIntIntervals(intervals ...modinterval.RealIntInterval) *Set {
+	s := Empty()
+	for _, r := range intervals {
+		if r.IsEmpty() {
+			continue
+		}
+		s.Insert(int64(r.Start()), int64(r.End()))
+	}
+	return s
+}
+
+// RealIntIntervals returns the ranges of the set as non-modular intervals. It
+// panics if a bound of the set does not fit in an int.
+func (s *Set) RealIntIntervals() []modinterval.RealIntInterval {
+	var result []modinterval.RealIntInterval
+	s.Intervals(func(lo, hi int64) bool {
+		result = append(result, modinterval.RealFromStartSize(toInt(lo), toInt(hi)-toInt(lo)))
+		return true
+	})
+	return result
+}
+
+func toInt(x int64) int {
+	if int64(int(x)) != x {
+		panic(fmt.Errorf("%d does not fit in an int", x))
+	}
+	return int(x)
+}
@@ -0,0 +1,112 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package intset
+
+import (
+	"fmt"
+
+	"github.com/google/go-intervals/intervalset"
+)
+
+func min(a, b int64) int64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// span is a private implementation of intervalset.Interval for the integers in
+// [lo, hi).
+type span struct {
+	lo, hi int64
+}
+
+func (s *span) String() string {
+	return fmt.Sprintf("[%d, %d)", s.lo, s.hi)
+}
+
+func spanOrPanic(i intervalset.Interval) *span {
+	s, ok := i.(*span)
+	if !ok {
+		panic(fmt.Errorf("interval must be an integer span: %v", i))
+	}
+	return s
+}
+
+// size returns the number of integers in the span.
+func (s *span) size() uint64 {
+	return uint64(s.hi) - uint64(s.lo)
+}
+
+func (s *span) intersect(b *span) *span {
+	result := &span{max(s.lo, b.lo), min(s.hi, b.hi)}
+	if result.lo < result.hi {
+		return result
+	}
+	return &span{}
+}
+
+func (s *span) IsZero() bool {
+	return s.lo == 0 && s.hi == 0
+}
+
+func (s *span) Before(other intervalset.Interval) bool {
+	return s.hi <= spanOrPanic(other).lo
+}
+
+func (s *span) Intersect(other intervalset.Interval) intervalset.Interval {
+	return s.intersect(spanOrPanic(other))
+}
+
+func (s *span) Bisect(other intervalset.Interval) (intervalset.Interval, intervalset.Interval) {
+	b := spanOrPanic(other)
+	intersection := s.intersect(b)
+	if intersection.IsZero() {
+		if s.Before(b) {
+			return s, &span{}
+		}
+		return &span{}, s
+	}
+	maybeZero := func(lo, hi int64) *span {
+		if lo == hi {
+			return &span{}
+		}
+		return &span{lo, hi}
+	}
+	return maybeZero(s.lo, intersection.lo), maybeZero(intersection.hi, s.hi)
+}
+
+func (s *span) Adjoin(other intervalset.Interval) intervalset.Interval {
+	b := spanOrPanic(other)
+	if s.hi == b.lo {
+		return &span{s.lo, b.hi}
+	}
+	if b.hi == s.lo {
+		return &span{b.lo, s.hi}
+	}
+	return &span{}
+}
+
+func (s *span) Encompass(other intervalset.Interval) intervalset.Interval {
+	b := spanOrPanic(other)
+	return &span{min(s.lo, b.lo), max(s.hi, b.hi)}
+}
@@ -0,0 +1,161 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package intset
+
+import (
+	"fmt"
+
+	"github.com/google/go-intervals/intervalset"
+)
+
+// Span is a range of integers that includes Lo and excludes Hi.
+type Span struct {
+	Lo int64 `json:"lo"`
+	Hi int64 `json:"hi"`
+}
+
+// String returns a human readable version of the span.
+func (s Span) String() string {
+	return fmt.Sprintf("[%d, %d)", s.Lo, s.Hi)
+}
+
+// Patch is a set of edits that can be applied to a Set: Removed is subtracted
+// from the set, then Added is added to it. Patches may be serialized, for
+// example with encoding/json.
+type Patch struct {
+	Added   []Span `json:"added,omitempty"`
+	Removed []Span `json:"removed,omitempty"`
+}
+
+// NewPatch returns a patch that transforms oldSet into newSet.
+func NewPatch(oldSet, newSet *Set) *Patch {
+	return fromIntervalPatch(intervalset.NewPatch(oldSet.iset, newSet.iset))
+}
+
+// IsEmpty reports whether applying the patch has no effect on any set.
+func (p *Patch) IsEmpty() bool {
+	return len(p.Added) == 0 && len(p.Removed) == 0
+}
+
+// Apply destructively modifies s by applying the patch to it.
+func (p *Patch) Apply(s *Set) {
+	s.index = nil
+	p.intervalPatch().Apply(s.iset)
+}
+
+// Then returns a patch that is equivalent to applying p followed by next.
+func (p *Patch) Then(next *Patch) *Patch {
+	return fromIntervalPatch(p.intervalPatch().Then(next.intervalPatch()))
+}
+
+func (p *Patch) intervalPatch() *intervalset.Patch {
+	return &intervalset.Patch{
+		Added:   spansToIntervals(p.Added),
+		Removed: spansToIntervals(p.Removed),
+	}
+}
+
+func fromIntervalPatch(p *intervalset.Patch) *Patch {
+	return &Patch{
+		Added:   intervalsToSpans(p.Added),
+		Removed: intervalsToSpans(p.Removed),
+	}
+}
+
+func spansToIntervals(spans []Span) []intervalset.Interval {
+	var result []intervalset.Interval
+	for _, s := range spans {
+		if s.Hi < s.Lo {
+			panic(fmt.Errorf("hi %d less than lo %d", s.Hi, s.Lo))
+		}
+		if s.Lo == s.Hi {
+			continue
+		}
+		result = append(result, &span{s.Lo, s.Hi})
+	}
+	return result
+}
+
+func intervalsToSpans(intervals []intervalset.Interval) []Span {
+	var result []Span
+	for _, x := range intervals {
+		sp := spanOrPanic(x)
+		result = append(result, Span{sp.lo, sp.hi})
+	}
+	return result
+}
+
+// Uint64Span is a range of uint64 values that includes Lo and excludes Hi.
+type Uint64Span struct {
+	Lo uint64 `json:"lo"`
+	Hi uint64 `json:"hi"`
+}
+
+// String returns a human readable version of the span.
+func (s Uint64Span) String() string {
+	return fmt.Sprintf("[%d, %d)", s.Lo, s.Hi)
+}
+
+// Uint64Patch is a set of edits that can be applied to a Uint64Set. It has the
+// same methods as Patch.
+type Uint64Patch struct {
+	Added   []Uint64Span `json:"added,omitempty"`
+	Removed []Uint64Span `json:"removed,omitempty"`
+}
+
+// NewUint64Patch returns a patch that transforms oldSet into newSet.
+func NewUint64Patch(oldSet, newSet *Uint64Set) *Uint64Patch {
+	return fromPatch(NewPatch(oldSet.set, newSet.set))
+}
+
+// IsEmpty reports whether applying the patch has no effect on any set.
+func (p *Uint64Patch) IsEmpty() bool {
+	return len(p.Added) == 0 && len(p.Removed) == 0
+}
+
+// Apply destructively modifies s by applying the patch to it.
+func (p *Uint64Patch) Apply(s *Uint64Set) {
+	p.patch().Apply(s.set)
+}
+
+// Then returns a patch that is equivalent to applying p followed by next.
+func (p *Uint64Patch) Then(next *Uint64Patch) *Uint64Patch {
+	return fromPatch(p.patch().Then(next.patch()))
+}
+
+func (p *Uint64Patch) patch() *Patch {
+	convert := func(spans []Uint64Span) []Span {
+		var result []Span
+		for _, s := range spans {
+			if s.Hi < s.Lo {
+				panic(fmt.Errorf("hi %d less than lo %d", s.Hi, s.Lo))
+			}
+			result = append(result, Span{fromUint64(s.Lo), fromUint64(s.Hi)})
+		}
+		return result
+	}
+	return &Patch{convert(p.Added), convert(p.Removed)}
+}
+
+func fromPatch(p *Patch) *Uint64Patch {
+	convert := func(spans []Span) []Uint64Span {
+		var result []Uint64Span
+		for _, s := range spans {
+			result = append(result, Uint64Span{toUint64(s.Lo), toUint64(s.Hi)})
+		}
+		return result
+	}
+	return &Uint64Patch{convert(p.Added), convert(p.Removed)}
+}
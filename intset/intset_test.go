@@ -0,0 +1,319 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package intset
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/google/go-intervals/modinterval"
+)
+
+type pair struct {
+	lo, hi int64
+}
+
+func pairs(s *Set) []pair {
+	result := []pair{}
+	s.Intervals(func(lo, hi int64) bool {
+		result = append(result, pair{lo, hi})
+		return true
+	})
+	return result
+}
+
+func setOf(ps ...pair) *Set {
+	s := Empty()
+	for _, p := range ps {
+		s.Insert(p.lo, p.hi)
+	}
+	return s
+}
+
+func TestSetOperations(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		got  *Set
+		want []pair
+	}{
+		{
+			name: "empty",
+			got:  Empty(),
+			want: []pair{},
+		},
+		{
+			name: "adjoining inserts coalesce",
+			got:  setOf(pair{0, 5}, pair{10, 15}, pair{5, 10}),
+			want: []pair{{0, 15}},
+		},
+		{
+			name: "insert spanning several ranges",
+			got:  setOf(pair{0, 2}, pair{4, 6}, pair{8, 10}, pair{20, 30}, pair{1, 9}),
+			want: []pair{{0, 10}, {20, 30}},
+		},
+		{
+			name: "sub",
+			got: func() *Set {
+				s := setOf(pair{-10, 10})
+				s.Sub(setOf(pair{-5, -3}, pair{0, 1}))
+				return s
+			}(),
+			want: []pair{{-10, -5}, {-3, 0}, {1, 10}},
+		},
+		{
+			name: "intersect",
+			got: func() *Set {
+				s := setOf(pair{0, 10}, pair{20, 30})
+				s.Intersect(setOf(pair{5, 25}))
+				return s
+			}(),
+			want: []pair{{5, 10}, {20, 25}},
+		},
+		{
+			name: "add",
+			got: func() *Set {
+				s := setOf(pair{0, 10}, pair{20, 30})
+				s.Add(setOf(pair{5, 12}, pair{15, 20}, pair{40, 50}))
+				return s
+			}(),
+			want: []pair{{0, 12}, {15, 30}, {40, 50}},
+		},
+	} {
+		if got := pairs(tt.got); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestQueries(t *testing.T) {
+	s := setOf(pair{-3, 0}, pair{5, 7}, pair{10, 11})
+
+	if got, want := s.Cardinality(), uint64(6); got != want {
+		t.Errorf("Cardinality() = %d, want %d", got, want)
+	}
+	if got, ok := s.Min(); !ok || got != -3 {
+		t.Errorf("Min() = %d, %t, want -3, true", got, ok)
+	}
+	if got, ok := s.Max(); !ok || got != 10 {
+		t.Errorf("Max() = %d, %t, want 10, true", got, ok)
+	}
+	if _, ok := Empty().Min(); ok {
+		t.Errorf("Empty().Min() reported a value")
+	}
+	for x, want := range map[int64]bool{-4: false, -3: true, -1: true, 0: false, 6: true, 7: false, 10: true, math.MaxInt64: false} {
+		if got := s.Contains(x); got != want {
+			t.Errorf("Contains(%d) = %t, want %t", x, got, want)
+		}
+	}
+	members := []int64{-3, -2, -1, 5, 6, 10}
+	for i, x := range members {
+		if got, ok := s.Select(uint64(i)); !ok || got != x {
+			t.Errorf("Select(%d) = %d, %t, want %d, true", i, got, ok, x)
+		}
+		if got := s.Rank(x); got != uint64(i) {
+			t.Errorf("Rank(%d) = %d, want %d", x, got, i)
+		}
+	}
+	if _, ok := s.Select(6); ok {
+		t.Errorf("Select(6) reported a value")
+	}
+	if got, want := s.Rank(100), uint64(6); got != want {
+		t.Errorf("Rank(100) = %d, want %d", got, want)
+	}
+}
+
+func TestPatches(t *testing.T) {
+	s := setOf(pair{0, 10})
+	if got, want := s.Insert(5, 15), (&Patch{Added: []Span{{10, 15}}}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Insert(5, 15) = %+v, want %+v", got, want)
+	}
+	if got := s.Insert(0, 15); !got.IsEmpty() {
+		t.Errorf("Insert(0, 15) = %+v, want an empty patch", got)
+	}
+	if got, want := s.Sub(setOf(pair{-5, 2}, pair{7, 8})), (&Patch{Removed: []Span{{0, 2}, {7, 8}}}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Sub() = %+v, want %+v", got, want)
+	}
+	if got, want := s.Intersect(setOf(pair{0, 12})), (&Patch{Removed: []Span{{12, 15}}}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Intersect() = %+v, want %+v", got, want)
+	}
+	if got, want := s.Add(setOf(pair{0, 3})), (&Patch{Added: []Span{{0, 2}}}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Add() = %+v, want %+v", got, want)
+	}
+
+	old, updated := setOf(pair{0, 5}), setOf(pair{3, 8})
+	NewPatch(old, updated).Apply(old)
+	if got, want := pairs(old), pairs(updated); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewPatch(old, updated).Apply(old) = %v, want %v", got, want)
+	}
+}
+
+func TestRankSelect(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	s := Empty()
+	var members []int64
+	for round := 0; round < 20; round++ {
+		lo := r.Int63n(1000)
+		if round%3 == 2 {
+			s.Sub(setOf(pair{lo, lo + r.Int63n(100)}))
+		} else {
+			s.Insert(lo, lo+r.Int63n(50))
+		}
+		members = members[:0]
+		s.Intervals(func(lo, hi int64) bool {
+			for x := lo; x < hi; x++ {
+				members = append(members, x)
+			}
+			return true
+		})
+		for x := int64(-1); x <= 1100; x++ {
+			want := uint64(sort.Search(len(members), func(i int) bool { return members[i] >= x }))
+			if got := s.Rank(x); got != want {
+				t.Fatalf("round %d: Rank(%d) = %d, want %d", round, x, got, want)
+			}
+		}
+		for i, want := range members {
+			if got, ok := s.Select(uint64(i)); !ok || got != want {
+				t.Fatalf("round %d: Select(%d) = %d, %t, want %d, true", round, i, got, ok, want)
+			}
+		}
+		if _, ok := s.Select(uint64(len(members))); ok {
+			t.Fatalf("round %d: Select(%d) reported a value", round, len(members))
+		}
+	}
+}
+
+func TestRealIntIntervals(t *testing.T) {
+	in := []modinterval.RealIntInterval{
+		modinterval.RealFromStartSize(10, 5),
+		modinterval.RealFromStartSize(0, 3),
+		modinterval.RealEmpty(),
+		modinterval.RealFromStartSize(3, 2),
+	}
+	s := FromRealIntIntervals(in...)
+	if got, want := pairs(s), []pair{{0, 5}, {10, 15}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FromRealIntIntervals() = %v, want %v", got, want)
+	}
+	want := []modinterval.RealIntInterval{
+		modinterval.RealFromStartSize(0, 5),
+		modinterval.RealFromStartSize(10, 5),
+	}
+	if got := s.RealIntIntervals(); !reflect.DeepEqual(got, want) {
+		t.Errorf("RealIntIntervals() = %v, want %v", got, want)
+	}
+	if got := Uint64FromRealIntIntervals(in...).RealIntIntervals(); !reflect.DeepEqual(got, want) {
+		t.Errorf("[Uint64Set] RealIntIntervals() = %v, want %v", got, want)
+	}
+}
+
+func TestUint64Set(t *testing.T) {
+	s := EmptyUint64()
+	s.Insert(0, 10)
+	s.Insert(math.MaxUint64-10, math.MaxUint64)
+	s.Insert(1<<63-1, 1<<63+1)
+
+	if got, want := s.String(), "{[0, 10), [9223372036854775807, 9223372036854775809), [18446744073709551605, 18446744073709551615)}"; got != want {
+		t.Errorf("String() = %s, want %s", got, want)
+	}
+	if got, want := s.Cardinality(), uint64(22); got != want {
+		t.Errorf("Cardinality() = %d, want %d", got, want)
+	}
+	if got, ok := s.Max(); !ok || got != math.MaxUint64-1 {
+		t.Errorf("Max() = %d, %t, want %d, true", got, ok, uint64(math.MaxUint64-1))
+	}
+	if got, ok := s.Select(10); !ok || got != 1<<63-1 {
+		t.Errorf("Select(10) = %d, %t, want %d, true", got, ok, uint64(1<<63-1))
+	}
+	if !s.Contains(1<<63) || s.Contains(10) {
+		t.Errorf("Contains() returned the wrong membership for 1<<63 or 10")
+	}
+	if got, want := s.Rank(1<<63), uint64(11); got != want {
+		t.Errorf("Rank(1<<63) = %d, want %d", got, want)
+	}
+
+	for name, f := range map[string]func() (uint64, bool){"Min": EmptyUint64().Min, "Max": EmptyUint64().Max} {
+		if got, ok := f(); ok || got != 0 {
+			t.Errorf("EmptyUint64().%s() = %d, %t, want 0, false", name, got, ok)
+		}
+	}
+
+	p := s.Copy().Insert(5, 20)
+	if want := []Uint64Span{{10, 20}}; !reflect.DeepEqual(p.Added, want) || len(p.Removed) != 0 {
+		t.Errorf("Insert(5, 20) = %+v, want added %v", p, want)
+	}
+
+	full := EmptyUint64()
+	full.Insert(0, math.MaxUint64)
+	if got, want := full.Cardinality(), uint64(math.MaxUint64); got != want {
+		t.Errorf("full.Cardinality() = %d, want %d", got, want)
+	}
+	full.Sub(s)
+	if got, want := full.Cardinality(), uint64(math.MaxUint64-22); got != want {
+		t.Errorf("full.Sub(s).Cardinality() = %d, want %d", got, want)
+	}
+}
+
+// sortedPairs is the baseline implementation used by the benchmarks: a sorted
+// slice of non-overlapping pairs.
+type sortedPairs []pair
+
+func (s sortedPairs) insert(lo, hi int64) sortedPairs {
+	i := sort.Search(len(s), func(i int) bool { return s[i].hi >= lo })
+	j := sort.Search(len(s), func(i int) bool { return s[i].lo > hi })
+	if i < j {
+		if s[i].lo < lo {
+			lo = s[i].lo
+		}
+		if s[j-1].hi > hi {
+			hi = s[j-1].hi
+		}
+	}
+	result := make(sortedPairs, 0, len(s)-(j-i)+1)
+	result = append(result, s[:i]...)
+	result = append(result, pair{lo, hi})
+	return append(result, s[j:]...)
+}
+
+func randomPairs(n int) []pair {
+	r := rand.New(rand.NewSource(1))
+	result := make([]pair, n)
+	for i := range result {
+		lo := r.Int63n(int64(n) * 100)
+		result[i] = pair{lo, lo + r.Int63n(50) + 1}
+	}
+	return result
+}
+
+func BenchmarkInsert(b *testing.B) {
+	ps := randomPairs(10000)
+	for n := 0; n < b.N; n++ {
+		s := Empty()
+		for _, p := range ps {
+			s.Insert(p.lo, p.hi)
+		}
+	}
+}
+
+func BenchmarkInsertSortedPairs(b *testing.B) {
+	ps := randomPairs(10000)
+	for n := 0; n < b.N; n++ {
+		var s sortedPairs
+		for _, p := range ps {
+			s = s.insert(p.lo, p.hi)
+		}
+	}
+}
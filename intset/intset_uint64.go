@@ -0,0 +1,214 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package intset
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/go-intervals/modinterval"
+)
+
+// Uint64Set is a finite set of uint64 values. It has the same methods as Set.
+//
+// Values are stored in a Set after flipping their most significant bit, which
+// maps uint64 values onto int64 values in an order-preserving way.
+type Uint64Set struct {
+	set *Set
+}
+
+func fromUint64(x uint64) int64 {
+	return int64(x ^ (1 << 63))
+}
+
+func toUint64(x int64) uint64 {
+	return uint64(x) ^ (1 << 63)
+}
+
+// EmptyUint64 returns a new, empty Uint64Set.
+func EmptyUint64() *Uint64Set {
+	return &Uint64Set{Empty()}
+}
+
+// String returns a human readable version of the set.
+func (s *Uint64Set) String() string {
+	var parts []string
+	s.Intervals(func(lo, hi uint64) bool {
+		parts = append(parts, fmt.Sprintf("[%d, %d)", lo, hi))
+		return true
+	})
+	return fmt.Sprintf("{%s}", strings.Join(parts, ", "))
+}
+
+// Copy returns a copy of a set that may be mutated without affecting the original.
+func (s *Uint64Set) Copy() *Uint64Set {
+	return &Uint64Set{s.set.Copy()}
+}
+
+// Insert adds the integers in [lo, hi) to the set. It returns a patch
+// describing the integers that were not already in the set. It panics if
+// hi < lo.
+func (s *Uint64Set) Insert(lo, hi uint64) *Uint64Patch {
+	if hi < lo {
+		panic(fmt.Errorf("hi %d less than lo %d", hi, lo))
+	}
+	return fromPatch(s.set.Insert(fromUint64(lo), fromUint64(hi)))
+}
+
+// Add performs an in-place union of two sets. It returns a patch describing
+// the ranges that were actually added.
+func (s *Uint64Set) Add(b *Uint64Set) *Uint64Patch {
+	return fromPatch(s.set.Add(b.set))
+}
+
+// Sub performs an in-place subtraction of set b from set s. It returns a patch
+// describing the ranges that were actually removed.
+func (s *Uint64Set) Sub(b *Uint64Set) *Uint64Patch {
+	return fromPatch(s.set.Sub(b.set))
+}
+
+// Intersect performs an in-place intersection of sets s and b. It returns a
+// patch describing the ranges that were actually removed.
+func (s *Uint64Set) Intersect(b *Uint64Set) *Uint64Patch {
+	return fromPatch(s.set.Intersect(b.set))
+}
+
+// Extent returns the range [lo, hi) covering the entire set. Both values are
+// zero for an empty set.
+func (s *Uint64Set) Extent() (lo, hi uint64) {
+	if s.Empty() {
+		return 0, 0
+	}
+	l, h := s.set.Extent()
+	return toUint64(l), toUint64(h)
+}
+
+// Empty reports whether the set has no members.
+func (s *Uint64Set) Empty() bool {
+	return s.set.Empty()
+}
+
+// Contains reports whether x is a member of the set.
+func (s *Uint64Set) Contains(x uint64) bool {
+	return s.set.Contains(fromUint64(x))
+}
+
+// ContainsRange reports whether all of the integers in [lo, hi) are members of
+// the set.
+func (s *Uint64Set) ContainsRange(lo, hi uint64) bool {
+	if hi <= lo {
+		return true
+	}
+	return s.set.ContainsRange(fromUint64(lo), fromUint64(hi))
+}
+
+// Cardinality returns the number of integers in the set.
+func (s *Uint64Set) Cardinality() uint64 {
+	return s.set.Cardinality()
+}
+
+// Min returns the smallest member of the set. The second return value is false
+// if the set is empty.
+func (s *Uint64Set) Min() (uint64, bool) {
+	x, ok := s.set.Min()
+	if !ok {
+		return 0, false
+	}
+	return toUint64(x), true
+}
+
+// Max returns the largest member of the set. The second return value is false
+// if the set is empty.
+func (s *Uint64Set) Max() (uint64, bool) {
+	x, ok := s.set.Max()
+	if !ok {
+		return 0, false
+	}
+	return toUint64(x), true
+}
+
+// Select returns the member of the set that has n smaller members, so
+// Select(0) is the smallest member. The second return value is false if
+// n >= s.Cardinality().
+func (s *Uint64Set) Select(n uint64) (uint64, bool) {
+	x, ok := s.set.Select(n)
+	if !ok {
+		return 0, false
+	}
+	return toUint64(x), true
+}
+
+// Rank returns the number of members of the set that are less than x.
+func (s *Uint64Set) Rank(x uint64) uint64 {
+	return s.set.Rank(fromUint64(x))
+}
+
+// Uint64IntervalReceiver is a function used for iterating over a set of
+// integer ranges. It takes the lower (inclusive) and upper (exclusive) bounds of
+// a range and returns true if the iteration should continue.
+type Uint64IntervalReceiver func(lo, hi uint64) bool
+
+// IntervalsBetween iterates over the ranges within the set and calls f with the
+// lower (inclusive) and upper (exclusive) bound of each. If f returns false,
+// iteration ceases. Ranges are truncated to [lo, hi).
+func (s *Uint64Set) IntervalsBetween(lo, hi uint64, f Uint64IntervalReceiver) {
+	if hi <= lo {
+		return
+	}
+	s.set.IntervalsBetween(fromUint64(lo), fromUint64(hi), func(l, h int64) bool {
+		return f(toUint64(l), toUint64(h))
+	})
+}
+
+// Intervals iterates over all the ranges within the set and calls f with the
+// lower (inclusive) and upper (exclusive) bound of each. If f returns false,
+// iteration ceases.
+func (s *Uint64Set) Intervals(f Uint64IntervalReceiver) {
+	s.set.Intervals(func(l, h int64) bool {
+		return f(toUint64(l), toUint64(h))
+	})
+}
+
+// Uint64FromRealIntIntervals returns a set containing the union of the given
+// intervals. It panics if an interval contains a negative value.
+func Uint64FromRealIntIntervals(intervals ...modinterval.RealIntInterval) *Uint64Set {
+	s := EmptyUint64()
+	for _, r := range intervals {
+		if r.IsEmpty() {
+			continue
+		}
+		if r.Start() < 0 {
+			panic(fmt.Errorf("interval %s contains negative values", r))
+		}
+		s.Insert(uint64(r.Start()), uint64(r.End()))
+	}
+	return s
+}
+
+// RealIntIntervals returns the ranges of the set as non-modular intervals. It
+// panics if a bound of the set does not fit in an int.
+func (s *Uint64Set) RealIntIntervals() []modinterval.RealIntInterval {
+	var result []modinterval.RealIntInterval
+	s.Intervals(func(lo, hi uint64) bool {
+		if hi > uint64(maxInt) {
+			panic(fmt.Errorf("%d does not fit in an int", hi))
+		}
+		result = append(result, modinterval.RealFromStartSize(int(lo), int(hi-lo)))
+		return true
+	})
+	return result
+}
+
+const maxInt = int(^uint(0) >> 1)
@@ -0,0 +1,218 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ipset is a finite set implementation for ranges of IP addresses.
+//
+// A set may hold both IPv4 and IPv6 addresses, but a single range may not span
+// both families. IPv4-mapped IPv6 addresses such as ::ffff:10.0.0.1 are treated
+// as IPv6 addresses; use netip.Addr.Unmap to convert them first if needed.
+// Addresses with an IPv6 zone are not supported.
+//
+// DISCLAIMER: This library is not yet stable, so expect breaking changes.
+package ipset
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/google/go-intervals/intervalset"
+)
+
+// ErrMixedFamilies is returned when the endpoints of a range are from
+// different IP families.
+var ErrMixedFamilies = errors.New("ipset: range mixes IPv4 and IPv6 addresses")
+
+// Set is a finite set of IP addresses. Functions are provided for iterating
+// over the ranges of the set and performing set operations (intersection,
+// union, subtraction).
+//
+// This is an IP-specific implementation of intervalset.Set.
+type Set struct {
+	iset *intervalset.Set
+}
+
+func makeZero() intervalset.Interval {
+	return &addrRange{}
+}
+
+// Empty returns a new, empty Set.
+func Empty() *Set {
+	return &Set{intervalset.EmptyV1(makeZero)}
+}
+
+// Parse returns a set containing the addresses described by each entry. An
+// entry may be a single address ("10.0.0.1"), a CIDR prefix ("10.0.0.0/8") or
+// an inclusive range of addresses ("10.0.0.1-10.0.0.9").
+func Parse(entries ...string) (*Set, error) {
+	s := Empty()
+	for _, e := range entries {
+		first, last, err := ParseRange(e)
+		if err != nil {
+			return nil, err
+		}
+		s.insert(first, last)
+	}
+	return s, nil
+}
+
+// ParseRange parses a single address, CIDR prefix or "first-last" range and
+// returns the first and last addresses it contains.
+func ParseRange(text string) (first, last netip.Addr, err error) {
+	text = strings.TrimSpace(text)
+	if strings.Contains(text, "/") {
+		p, err := netip.ParsePrefix(text)
+		if err != nil {
+			return netip.Addr{}, netip.Addr{}, err
+		}
+		p = p.Masked()
+		return p.Addr(), lastAddr(p), nil
+	}
+	if i := strings.IndexByte(text, '-'); i >= 0 {
+		if first, err = netip.ParseAddr(strings.TrimSpace(text[:i])); err != nil {
+			return netip.Addr{}, netip.Addr{}, err
+		}
+		if last, err = netip.ParseAddr(strings.TrimSpace(text[i+1:])); err != nil {
+			return netip.Addr{}, netip.Addr{}, err
+		}
+	} else {
+		if first, err = netip.ParseAddr(text); err != nil {
+			return netip.Addr{}, netip.Addr{}, err
+		}
+		last = first
+	}
+	if err := checkRange(first, last); err != nil {
+		return netip.Addr{}, netip.Addr{}, err
+	}
+	return first, last, nil
+}
+
+// checkRange returns an error if [first, last] is not a valid range.
+func checkRange(first, last netip.Addr) error {
+	switch {
+	case !first.IsValid() || !last.IsValid():
+		return fmt.Errorf("ipset: invalid address in range %v-%v", first, last)
+	case first.Zone() != "" || last.Zone() != "":
+		return fmt.Errorf("ipset: zoned address in range %v-%v", first, last)
+	case first.Is4() != last.Is4():
+		return fmt.Errorf("%w: %v-%v", ErrMixedFamilies, first, last)
+	case last.Less(first):
+		return fmt.Errorf("ipset: last address %v before first address %v", last, first)
+	}
+	return nil
+}
+
+// String returns a human readable version of the set.
+func (s *Set) String() string {
+	return s.iset.String()
+}
+
+// Copy returns a copy of a set that may be mutated without affecting the original.
+func (s *Set) Copy() *Set {
+	return &Set{s.iset.Copy()}
+}
+
+func (s *Set) insert(first, last netip.Addr) {
+	s.iset.Add(intervalset.NewSetV1([]intervalset.Interval{&addrRange{first, last}}, makeZero))
+}
+
+// InsertPrefix adds the addresses of a CIDR prefix to the set. Host bits of
+// the prefix address are ignored.
+func (s *Set) InsertPrefix(p netip.Prefix) error {
+	if !p.IsValid() {
+		return fmt.Errorf("ipset: invalid prefix %v", p)
+	}
+	p = p.Masked()
+	s.insert(p.Addr(), lastAddr(p))
+	return nil
+}
+
+// InsertRange adds the inclusive range of addresses [first, last] to the set.
+// It returns an error wrapping ErrMixedFamilies if first and last are from
+// different families, and an error if last is before first.
+func (s *Set) InsertRange(first, last netip.Addr) error {
+	if err := checkRange(first, last); err != nil {
+		return err
+	}
+	s.insert(first, last)
+	return nil
+}
+
+// Add performs an in-place union of two sets. It returns a patch describing
+// the ranges that were actually added.
+func (s *Set) Add(b *Set) *Patch {
+	return fromIntervalPatch(s.iset.Add(b.iset))
+}
+
+// Sub performs an in-place subtraction of set b from set s. It returns a patch
+// describing the ranges that were actually removed.
+func (s *Set) Sub(b *Set) *Patch {
+	return fromIntervalPatch(s.iset.Sub(b.iset))
+}
+
+// Intersect performs an in-place intersection of sets s and b. It returns a
+// patch describing the ranges that were actually removed.
+func (s *Set) Intersect(b *Set) *Patch {
+	return fromIntervalPatch(s.iset.Intersect(b.iset))
+}
+
+// Empty reports whether the set has no members.
+func (s *Set) Empty() bool {
+	return s.iset.Extent().IsZero()
+}
+
+// Contains reports whether addr is a member of the set. Invalid and zoned
+// addresses are never members.
+func (s *Set) Contains(addr netip.Addr) bool {
+	if checkRange(addr, addr) != nil {
+		return false
+	}
+	return s.iset.Contains(&addrRange{addr, addr})
+}
+
+// ContainsPrefix reports whether every address of p is a member of the set.
+func (s *Set) ContainsPrefix(p netip.Prefix) bool {
+	if !p.IsValid() {
+		return false
+	}
+	p = p.Masked()
+	return s.iset.Contains(&addrRange{p.Addr(), lastAddr(p)})
+}
+
+// RangeReceiver is a function used for iterating over the ranges of a set. It
+// takes the first and last (both inclusive) addresses of a range and returns
+// true if the iteration should continue.
+type RangeReceiver func(first, last netip.Addr) bool
+
+// Ranges iterates over the ranges within the set in increasing order, IPv4
+// before IPv6, and calls f with the first and last address of each. If f
+// returns false, iteration ceases.
+func (s *Set) Ranges(f RangeReceiver) {
+	s.iset.Intervals(func(x intervalset.Interval) bool {
+		r := rangeOrPanic(x)
+		return f(r.first, r.last)
+	})
+}
+
+// Prefixes returns the minimal list of CIDR prefixes whose union is the set,
+// in increasing order.
+func (s *Set) Prefixes() []netip.Prefix {
+	var result []netip.Prefix
+	s.iset.Intervals(func(x intervalset.Interval) bool {
+		result = rangeOrPanic(x).prefixes(result)
+		return true
+	})
+	return result
+}
@@ -0,0 +1,149 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipset
+
+import (
+	"fmt"
+	"net/netip"
+
+	"github.com/google/go-intervals/intervalset"
+)
+
+// addrRange is a private implementation of intervalset.Interval for the closed
+// range [first, last] of IP addresses.
+//
+// A closed range is used because the last address of each family has no
+// successor that could serve as an exclusive upper bound. The zero range has
+// invalid endpoints. Every IPv4 address sorts before every IPv6 address, so
+// ranges of both families can share a set without overlapping.
+type addrRange struct {
+	first, last netip.Addr
+}
+
+func (r *addrRange) String() string {
+	if r.first == r.last {
+		return r.first.String()
+	}
+	return fmt.Sprintf("%s-%s", r.first, r.last)
+}
+
+func rangeOrPanic(i intervalset.Interval) *addrRange {
+	r, ok := i.(*addrRange)
+	if !ok {
+		panic(fmt.Errorf("interval must be an address range: %v", i))
+	}
+	return r
+}
+
+func minAddr(a, b netip.Addr) netip.Addr {
+	if a.Less(b) {
+		return a
+	}
+	return b
+}
+
+func maxAddr(a, b netip.Addr) netip.Addr {
+	if a.Less(b) {
+		return b
+	}
+	return a
+}
+
+func (r *addrRange) intersect(b *addrRange) *addrRange {
+	result := &addrRange{maxAddr(r.first, b.first), minAddr(r.last, b.last)}
+	if result.last.Less(result.first) {
+		return &addrRange{}
+	}
+	return result
+}
+
+func (r *addrRange) IsZero() bool {
+	return !r.first.IsValid()
+}
+
+func (r *addrRange) Before(other intervalset.Interval) bool {
+	return r.last.Less(rangeOrPanic(other).first)
+}
+
+func (r *addrRange) Intersect(other intervalset.Interval) intervalset.Interval {
+	return r.intersect(rangeOrPanic(other))
+}
+
+func (r *addrRange) Bisect(other intervalset.Interval) (intervalset.Interval, intervalset.Interval) {
+	b := rangeOrPanic(other)
+	intersection := r.intersect(b)
+	if intersection.IsZero() {
+		if r.Before(b) {
+			return r, &addrRange{}
+		}
+		return &addrRange{}, r
+	}
+	before, after := &addrRange{}, &addrRange{}
+	if r.first.Less(intersection.first) {
+		before = &addrRange{r.first, intersection.first.Prev()}
+	}
+	if intersection.last.Less(r.last) {
+		after = &addrRange{intersection.last.Next(), r.last}
+	}
+	return before, after
+}
+
+func (r *addrRange) Adjoin(other intervalset.Interval) intervalset.Interval {
+	b := rangeOrPanic(other)
+	if r.last.Next() == b.first {
+		return &addrRange{r.first, b.last}
+	}
+	if b.last.Next() == r.first {
+		return &addrRange{b.first, r.last}
+	}
+	return &addrRange{}
+}
+
+func (r *addrRange) Encompass(other intervalset.Interval) intervalset.Interval {
+	b := rangeOrPanic(other)
+	return &addrRange{minAddr(r.first, b.first), maxAddr(r.last, b.last)}
+}
+
+// prefixes appends the minimal list of CIDR prefixes covering the range to
+// result.
+func (r *addrRange) prefixes(result []netip.Prefix) []netip.Prefix {
+	first := r.first
+	for {
+		// Find the shortest prefix that starts at first and ends within the range.
+		var p netip.Prefix
+		for bits := 0; bits <= first.BitLen(); bits++ {
+			p = netip.PrefixFrom(first, bits).Masked()
+			if p.Addr() == first && !r.last.Less(lastAddr(p)) {
+				break
+			}
+		}
+		result = append(result, p)
+		end := lastAddr(p)
+		if end == r.last {
+			return result
+		}
+		first = end.Next()
+	}
+}
+
+// lastAddr returns the last address of a masked prefix.
+func lastAddr(p netip.Prefix) netip.Addr {
+	b := p.Addr().AsSlice()
+	for i := p.Bits(); i < len(b)*8; i++ {
+		b[i/8] |= 0x80 >> (i % 8)
+	}
+	a, _ := netip.AddrFromSlice(b)
+	return a
+}
@@ -0,0 +1,180 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipset
+
+import (
+	"encoding/json"
+	"errors"
+	"net/netip"
+	"reflect"
+	"testing"
+)
+
+func mustParse(t *testing.T, entries ...string) *Set {
+	t.Helper()
+	s, err := Parse(entries...)
+	if err != nil {
+		t.Fatalf("Parse(%q) = %v", entries, err)
+	}
+	return s
+}
+
+func prefixStrings(s *Set) []string {
+	result := []string{}
+	for _, p := range s.Prefixes() {
+		result = append(result, p.String())
+	}
+	return result
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, text := range []string{
+		"",
+		"10.0.0.0/33",
+		"10.0.0.300",
+		"10.0.0.9-10.0.0.1",
+		"fe80::1%eth0",
+	} {
+		if _, err := Parse(text); err == nil {
+			t.Errorf("Parse(%q) = nil error, want error", text)
+		}
+	}
+	if _, err := Parse("10.0.0.1-::1"); !errors.Is(err, ErrMixedFamilies) {
+		t.Errorf("Parse(mixed families) = %v, want ErrMixedFamilies", err)
+	}
+	err := Empty().InsertRange(netip.MustParseAddr("::1"), netip.MustParseAddr("10.0.0.1"))
+	if !errors.Is(err, ErrMixedFamilies) {
+		t.Errorf("InsertRange(mixed families) = %v, want ErrMixedFamilies", err)
+	}
+}
+
+func TestPrefixes(t *testing.T) {
+	for _, tt := range []struct {
+		entries []string
+		want    []string
+	}{
+		{
+			entries: []string{"10.0.0.1/8"},
+			want:    []string{"10.0.0.0/8"},
+		},
+		{
+			entries: []string{"10.0.0.0/25", "10.0.0.128/25"},
+			want:    []string{"10.0.0.0/24"},
+		},
+		{
+			entries: []string{"10.0.0.1-10.0.0.6"},
+			want:    []string{"10.0.0.1/32", "10.0.0.2/31", "10.0.0.4/31", "10.0.0.6/32"},
+		},
+		{
+			entries: []string{"0.0.0.0-255.255.255.255"},
+			want:    []string{"0.0.0.0/0"},
+		},
+		{
+			entries: []string{"::/0", "192.168.1.1"},
+			want:    []string{"192.168.1.1/32", "::/0"},
+		},
+		{
+			entries: []string{"2001:db8::ffff-2001:db8::1:0"},
+			want:    []string{"2001:db8::ffff/128", "2001:db8::1:0/128"},
+		},
+	} {
+		if got := prefixStrings(mustParse(t, tt.entries...)); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Parse(%q).Prefixes() = %v, want %v", tt.entries, got, tt.want)
+		}
+	}
+}
+
+func TestSetOperations(t *testing.T) {
+	allow := mustParse(t, "10.0.0.0/8", "2001:db8::/32")
+	deny := mustParse(t, "10.1.0.0/16", "10.0.0.0", "2001:db8::/33")
+
+	s := allow.Copy()
+	s.Sub(deny)
+	want := []string{
+		"10.0.0.1/32", "10.0.0.2/31", "10.0.0.4/30", "10.0.0.8/29", "10.0.0.16/28",
+		"10.0.0.32/27", "10.0.0.64/26", "10.0.0.128/25", "10.0.1.0/24", "10.0.2.0/23",
+		"10.0.4.0/22", "10.0.8.0/21", "10.0.16.0/20", "10.0.32.0/19", "10.0.64.0/18",
+		"10.0.128.0/17", "10.2.0.0/15", "10.4.0.0/14", "10.8.0.0/13", "10.16.0.0/12",
+		"10.32.0.0/11", "10.64.0.0/10", "10.128.0.0/9", "2001:db8:8000::/33",
+	}
+	if got := prefixStrings(s); !reflect.DeepEqual(got, want) {
+		t.Errorf("Sub() = %v, want %v", got, want)
+	}
+
+	s.Add(deny)
+	if got, want := s.String(), "{10.0.0.0-10.255.255.255, 2001:db8::-2001:db8:ffff:ffff:ffff:ffff:ffff:ffff}"; got != want {
+		t.Errorf("Add() = %s, want %s", got, want)
+	}
+
+	s.Intersect(mustParse(t, "10.255.255.255-11.0.0.0", "::/0"))
+	if got, want := prefixStrings(s), []string{"10.255.255.255/32", "2001:db8::/32"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Intersect() = %v, want %v", got, want)
+	}
+}
+
+func TestPatches(t *testing.T) {
+	addr := netip.MustParseAddr
+	s := mustParse(t, "10.0.0.0/24")
+	if got, want := s.Sub(mustParse(t, "10.0.0.128-10.0.1.5")), (&Patch{Removed: []Range{{addr("10.0.0.128"), addr("10.0.0.255")}}}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Sub() = %+v, want %+v", got, want)
+	}
+	if got, want := s.Add(mustParse(t, "10.0.0.0/25", "::1")), (&Patch{Added: []Range{{addr("::1"), addr("::1")}}}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Add() = %+v, want %+v", got, want)
+	}
+	if got, want := s.Intersect(mustParse(t, "10.0.0.0/8")), (&Patch{Removed: []Range{{addr("::1"), addr("::1")}}}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Intersect() = %+v, want %+v", got, want)
+	}
+
+	old, updated := mustParse(t, "10.0.0.0/8"), mustParse(t, "10.1.0.0/16", "2001:db8::/32")
+	encoded, err := json.Marshal(NewPatch(old, updated))
+	if err != nil {
+		t.Fatalf("json.Marshal() = %v", err)
+	}
+	decoded := &Patch{}
+	if err := json.Unmarshal(encoded, decoded); err != nil {
+		t.Fatalf("json.Unmarshal(%s) = %v", encoded, err)
+	}
+	decoded.Apply(old)
+	if got, want := old.String(), updated.String(); got != want {
+		t.Errorf("patched old = %s, want %s", got, want)
+	}
+}
+
+func TestContains(t *testing.T) {
+	s := mustParse(t, "10.0.0.0/8", "192.168.0.1-192.168.0.9", "fd00::/8")
+	for _, tt := range []struct {
+		addr string
+		want bool
+	}{
+		{"10.0.0.0", true},
+		{"10.255.255.255", true},
+		{"11.0.0.0", false},
+		{"192.168.0.9", true},
+		{"192.168.0.10", false},
+		{"fdff::1", true},
+		{"fe00::", false},
+		{"::ffff:10.0.0.1", false},
+	} {
+		if got := s.Contains(netip.MustParseAddr(tt.addr)); got != tt.want {
+			t.Errorf("Contains(%s) = %v, want %v", tt.addr, got, tt.want)
+		}
+	}
+	if s.Contains(netip.Addr{}) {
+		t.Errorf("Contains(invalid address) = true, want false")
+	}
+	if !s.ContainsPrefix(netip.MustParsePrefix("10.20.0.0/16")) || s.ContainsPrefix(netip.MustParsePrefix("192.168.0.0/28")) {
+		t.Errorf("ContainsPrefix gave unexpected results for %v", s)
+	}
+}
@@ -464,6 +464,34 @@ func (r RealIntInterval) Add(offset int) RealIntInterval {
 	return RealFromStartSize(r.Start()+offset, r.Size())
 }
 
+// Translate returns an interval shifted by offset. It is equivalent to
+// r.Add(offset).
+func (r RealIntInterval) Translate(offset int) RealIntInterval {
+	return r.Add(offset)
+}
+
+// Scale returns the interval with each endpoint x replaced by
+// origin + (x - origin) * factor.
+//
+// The interval is treated as the continuous range [Start(), End()), so a
+// negative factor maps [a, b) to [origin + (b - origin) * factor,
+// origin + (a - origin) * factor).
+func (r RealIntInterval) Scale(origin, factor int) RealIntInterval {
+	return r.MapEndpoints(func(x int) int {
+		return origin + (x-origin)*factor
+	})
+}
+
+// MapEndpoints returns the interval [f(Start()), f(End())). If f(End()) is less
+// than f(Start()), the endpoints are swapped.
+func (r RealIntInterval) MapEndpoints(f func(int) int) RealIntInterval {
+	start, end := f(r.Start()), f(r.End())
+	if end < start {
+		start, end = end, start
+	}
+	return RealFromStartSize(start, end-start)
+}
+
 func intMin(a, b int) int {
 	if a < b {
 		return a
@@ -469,3 +469,36 @@ func TestRealIntInterval(t *testing.T) {
 		})
 	}
 }
+
+func TestRealIntIntervalTransform(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		got  RealIntInterval
+		want RealIntInterval
+	}{
+		{
+			name: "translate [3, 5] by 10",
+			got:  RealFromStartSize(3, 3).Translate(10),
+			want: RealFromStartSize(13, 3),
+		},
+		{
+			name: "scale [3, 5] by 2 about 3",
+			got:  RealFromStartSize(3, 3).Scale(3, 2),
+			want: RealFromStartSize(3, 6),
+		},
+		{
+			name: "scale [3, 5] by -1 about 0",
+			got:  RealFromStartSize(3, 3).Scale(0, -1),
+			want: RealFromStartSize(-6, 3),
+		},
+		{
+			name: "map endpoints of [3, 5] with x*x",
+			got:  RealFromStartSize(3, 3).MapEndpoints(func(x int) int { return x * x }),
+			want: RealFromStartSize(9, 27),
+		},
+	} {
+		if diff := cmp.Diff(tt.want, tt.got, cmpOpts...); diff != "" {
+			t.Errorf("%s: unexpected diff (-want +got):\n%s", tt.name, diff)
+		}
+	}
+}
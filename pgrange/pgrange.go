@@ -0,0 +1,209 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pgrange stores sets in PostgreSQL range and multirange columns using
+// database/sql.
+//
+// TimeRange and TimeMultirange adapt a timespanset.Set to the tstzrange and
+// tstzmultirange types, and IntRange and IntMultirange adapt an intset.Set to
+// the int8range and int8multirange types. Each implements sql.Scanner and
+// driver.Valuer using the text representation of the PostgreSQL types:
+//
+//	var s pgrange.TimeMultirange
+//	err := db.QueryRow("SELECT busy FROM schedules WHERE id = $1", id).Scan(&s)
+//	_, err = db.Exec("UPDATE schedules SET busy = $1 WHERE id = $2", s, id)
+//
+// DISCLAIMER: This library is not yet stable, so expect breaking changes.
+package pgrange
+
+import (
+	"fmt"
+	"strings"
+)
+
+// bound is one end of a range in its text representation.
+type bound struct {
+	// text is the value of the bound, with quoting removed.
+	text string
+	// unbounded is true if the bound was omitted.
+	unbounded bool
+	// inclusive is true if the bound was given with a square bracket.
+	inclusive bool
+}
+
+// rawRange is a range in its text representation.
+type rawRange struct {
+	empty        bool
+	lower, upper bound
+}
+
+// scanText returns the text of a value passed to Scan.
+func scanText(src interface{}) (string, error) {
+	switch src := src.(type) {
+	case string:
+		return src, nil
+	case []byte:
+		return string(src), nil
+	}
+	return "", fmt.Errorf("pgrange: can not scan %T", src)
+}
+
+// parseRanges parses a range or a multirange, such as "[1,5)" or
+// "{[1,5),[7,8)}".
+func parseRanges(text string) ([]rawRange, error) {
+	p := &parser{text: strings.TrimSpace(text)}
+	var result []rawRange
+	if p.consume('{') {
+		p.skipSpace()
+		if !p.consume('}') {
+			for {
+				r, err := p.parseRange()
+				if err != nil {
+					return nil, err
+				}
+				result = append(result, r)
+				p.skipSpace()
+				if p.consume('}') {
+					break
+				}
+				if !p.consume(',') {
+					return nil, p.errorf("want , or }")
+				}
+			}
+		}
+	} else {
+		r, err := p.parseRange()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, r)
+	}
+	p.skipSpace()
+	if p.pos != len(p.text) {
+		return nil, p.errorf("unexpected text after range")
+	}
+	return result, nil
+}
+
+type parser struct {
+	text string
+	pos  int
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("pgrange: invalid range %q at offset %d: %s", p.text, p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) skipSpace() {
+	for p.pos < len(p.text) && strings.IndexByte(" \t\r\n", p.text[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+// consume advances past c if it is the next character.
+func (p *parser) consume(c byte) bool {
+	if p.pos < len(p.text) && p.text[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) parseRange() (rawRange, error) {
+	p.skipSpace()
+	if rest := p.text[p.pos:]; len(rest) >= 5 && strings.EqualFold(rest[:5], "empty") {
+		p.pos += 5
+		return rawRange{empty: true}, nil
+	}
+	var r rawRange
+	switch {
+	case p.consume('['):
+		r.lower.inclusive = true
+	case p.consume('('):
+	default:
+		return r, p.errorf("want [ or (")
+	}
+	var err error
+	if r.lower, err = p.parseBound(r.lower, ','); err != nil {
+		return r, err
+	}
+	if !p.consume(',') {
+		return r, p.errorf("want ,")
+	}
+	if r.upper, err = p.parseBound(r.upper, ']', ')'); err != nil {
+		return r, err
+	}
+	switch {
+	case p.consume(']'):
+		r.upper.inclusive = true
+	case p.consume(')'):
+	default:
+		return r, p.errorf("want ] or )")
+	}
+	return r, nil
+}
+
+// parseBound parses the value of a bound, which ends at one of the given
+// characters outside of quotes.
+func (p *parser) parseBound(b bound, ends ...byte) (bound, error) {
+	var text strings.Builder
+	quoted := false
+	start := p.pos
+	for {
+		if p.pos == len(p.text) {
+			return b, p.errorf("unterminated range")
+		}
+		c := p.text[p.pos]
+		switch {
+		case c == '"':
+			quoted = !quoted
+		case c == '\\':
+			p.pos++
+			if p.pos == len(p.text) {
+				return b, p.errorf("unterminated escape")
+			}
+			text.WriteByte(p.text[p.pos])
+		case !quoted && strings.IndexByte(string(ends), c) >= 0:
+			b.text = text.String()
+			b.unbounded = p.pos == start
+			return b, nil
+		default:
+			text.WriteByte(c)
+		}
+		p.pos++
+	}
+}
+
+// formatRange returns the text representation of a range with an inclusive
+// lower bound and an exclusive upper bound. An empty string means the bound is
+// omitted.
+func formatRange(lower, upper string) string {
+	if lower == "" {
+		return "(" + "," + quote(upper) + ")"
+	}
+	return "[" + quote(lower) + "," + quote(upper) + ")"
+}
+
+// quote quotes a bound value if needed.
+func quote(text string) string {
+	if text == "" || !strings.ContainsAny(text, ` ,()[]{}"\`) {
+		return text
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(text) + `"`
+}
+
+// formatMultirange returns the text representation of a multirange.
+func formatMultirange(ranges []string) string {
+	return "{" + strings.Join(ranges, ",") + "}"
+}
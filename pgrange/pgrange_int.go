@@ -0,0 +1,159 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pgrange
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/google/go-intervals/intset"
+)
+
+// IntRange adapts an intset.Set to a PostgreSQL int8range column. The set
+// must contain at most one range to be stored.
+//
+// Scanning NULL sets Set to nil, and a nil Set is stored as NULL. An unbounded
+// lower bound is scanned as math.MinInt64 and an unbounded upper bound as
+// math.MaxInt64, and those values are stored as unbounded.
+type IntRange struct {
+	Set *intset.Set
+}
+
+// IntMultirange adapts an intset.Set to a PostgreSQL int8multirange column.
+// It handles NULL and bounds as IntRange does.
+type IntMultirange struct {
+	Set *intset.Set
+}
+
+var (
+	_ sql.Scanner   = (*IntRange)(nil)
+	_ driver.Valuer = IntRange{}
+	_ sql.Scanner   = (*IntMultirange)(nil)
+	_ driver.Valuer = IntMultirange{}
+)
+
+// Scan implements sql.Scanner. It accepts a range or a multirange.
+func (r *IntRange) Scan(src interface{}) error {
+	s, err := scanInts(src)
+	if err == nil {
+		r.Set = s
+	}
+	return err
+}
+
+// Value implements driver.Valuer. It returns an error if the set contains
+// more than one range.
+func (r IntRange) Value() (driver.Value, error) {
+	if r.Set == nil {
+		return nil, nil
+	}
+	ranges := intRanges(r.Set)
+	switch len(ranges) {
+	case 0:
+		return "empty", nil
+	case 1:
+		return ranges[0], nil
+	}
+	return nil, fmt.Errorf("pgrange: an int8range can not hold %d ranges: %s", len(ranges), r.Set)
+}
+
+// Scan implements sql.Scanner. It accepts a range or a multirange.
+func (r *IntMultirange) Scan(src interface{}) error {
+	s, err := scanInts(src)
+	if err == nil {
+		r.Set = s
+	}
+	return err
+}
+
+// Value implements driver.Valuer.
+func (r IntMultirange) Value() (driver.Value, error) {
+	if r.Set == nil {
+		return nil, nil
+	}
+	return formatMultirange(intRanges(r.Set)), nil
+}
+
+func scanInts(src interface{}) (*intset.Set, error) {
+	if src == nil {
+		return nil, nil
+	}
+	text, err := scanText(src)
+	if err != nil {
+		return nil, err
+	}
+	ranges, err := parseRanges(text)
+	if err != nil {
+		return nil, err
+	}
+	result := intset.Empty()
+	for _, r := range ranges {
+		if r.empty {
+			continue
+		}
+		lo, hi := int64(math.MinInt64), int64(math.MaxInt64)
+		if !r.lower.unbounded {
+			if lo, err = parseIntBound(r.lower, false); err != nil {
+				return nil, err
+			}
+		}
+		if !r.upper.unbounded {
+			if hi, err = parseIntBound(r.upper, true); err != nil {
+				return nil, err
+			}
+		}
+		if hi < lo {
+			return nil, fmt.Errorf("pgrange: range %q has upper bound before lower bound", text)
+		}
+		result.Insert(lo, hi)
+	}
+	return result, nil
+}
+
+// parseIntBound returns the value of a bound in the canonical form of an
+// int8range, with an inclusive lower bound and an exclusive upper bound.
+func parseIntBound(b bound, upper bool) (int64, error) {
+	x, err := strconv.ParseInt(b.text, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("pgrange: invalid int8 %q", b.text)
+	}
+	if b.inclusive == upper {
+		if x == math.MaxInt64 {
+			return 0, fmt.Errorf("pgrange: int8 bound %q out of range", b.text)
+		}
+		x++
+	}
+	return x, nil
+}
+
+// intRanges returns the text representation of each range of s.
+func intRanges(s *intset.Set) []string {
+	var result []string
+	s.Intervals(func(lo, hi int64) bool {
+		var lower, upper string
+		if lo != math.MinInt64 {
+			lower = strconv.FormatInt(lo, 10)
+		}
+		if hi != math.MaxInt64 {
+			upper = strconv.FormatInt(hi, 10)
+		}
+		result = append(result, formatRange(lower, upper))
+		return true
+	})
+	return result
+}
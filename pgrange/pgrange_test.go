@@ -0,0 +1,169 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pgrange
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-intervals/intset"
+	"github.com/google/go-intervals/timespanset"
+)
+
+func TestIntMultirange(t *testing.T) {
+	for _, tt := range []struct {
+		wire, want, stored string
+	}{
+		{"{}", "{}", "{}"},
+		{"empty", "{}", "{}"},
+		{"[1,5)", "{[1, 5)}", "{[1,5)}"},
+		{"(1,5]", "{[2, 6)}", "{[2,6)}"},
+		{"{[1,3), [3,5], empty, (9,10]}", "{[1, 6), [10, 11)}", "{[1,6),[10,11)}"},
+		{`["-3","4")`, "{[-3, 4)}", "{[-3,4)}"},
+		{"(,0)", "{[-9223372036854775808, 0)}", "{(,0)}"},
+		{"[7,)", "{[7, 9223372036854775807)}", "{[7,)}"},
+		{"(,)", "{[-9223372036854775808, 9223372036854775807)}", "{(,)}"},
+	} {
+		var r IntMultirange
+		if err := r.Scan([]byte(tt.wire)); err != nil {
+			t.Errorf("Scan(%q) failed: %v", tt.wire, err)
+			continue
+		}
+		if got := r.Set.String(); got != tt.want {
+			t.Errorf("Scan(%q) = %s, want %s", tt.wire, got, tt.want)
+		}
+		if got, err := r.Value(); err != nil || got != tt.stored {
+			t.Errorf("Scan(%q).Value() = %v, %v, want %s", tt.wire, got, err, tt.stored)
+		}
+	}
+
+	for _, wire := range []string{"", "[1,5", "[5,1)", "[a,5)", "{[1,2)", "{[1,2)}x", "[1,9223372036854775807]", "(9223372036854775807,)"} {
+		var r IntMultirange
+		if err := r.Scan(wire); err == nil {
+			t.Errorf("Scan(%q) = %s, want an error", wire, r.Set)
+		}
+	}
+}
+
+func TestIntRange(t *testing.T) {
+	s := intset.Empty()
+	r := IntRange{s}
+	if got, err := r.Value(); err != nil || got != "empty" {
+		t.Errorf("Value() of the empty set = %v, %v, want empty", got, err)
+	}
+	s.Insert(1, 5)
+	if got, err := r.Value(); err != nil || got != "[1,5)" {
+		t.Errorf("Value() = %v, %v, want [1,5)", got, err)
+	}
+	s.Insert(7, 8)
+	if _, err := r.Value(); err == nil {
+		t.Errorf("Value() of two ranges succeeded, want an error")
+	}
+	if got, err := (IntRange{}).Value(); err != nil || got != nil {
+		t.Errorf("Value() of a nil set = %v, %v, want NULL", got, err)
+	}
+	if err := r.Scan(nil); err != nil || r.Set != nil {
+		t.Errorf("Scan(nil) = %v and set %s, want a nil set", err, r.Set)
+	}
+}
+
+func TestTimeMultirange(t *testing.T) {
+	at := func(day, hour int) time.Time {
+		return time.Date(2015, time.June, day, hour, 0, 0, 0, time.UTC)
+	}
+	spans := func(s *timespanset.Set) []timespanset.Span {
+		var result []timespanset.Span
+		first, last := s.Extent()
+		s.IntervalsBetween(first, last, func(start, end time.Time) bool {
+			result = append(result, timespanset.Span{Start: start, End: end})
+			return true
+		})
+		return result
+	}
+	for _, tt := range []struct {
+		wire   string
+		want   []timespanset.Span
+		stored string
+	}{
+		{
+			wire:   `{["2015-06-01 00:00:00+00","2015-06-08 00:00:00+00")}`,
+			want:   []timespanset.Span{{Start: at(1, 0), End: at(8, 0)}},
+			stored: `{["2015-06-01 00:00:00+00","2015-06-08 00:00:00+00")}`,
+		},
+		{
+			wire:   `["2015-06-01 02:00:00+02","2015-06-01 10:30:00+05:30"]`,
+			want:   []timespanset.Span{{Start: at(1, 0), End: at(1, 5).Add(time.Microsecond)}},
+			stored: `{["2015-06-01 00:00:00+00","2015-06-01 05:00:00.000001+00")}`,
+		},
+		{
+			wire:   `{("2015-06-01 00:00:00.5+00",), [-infinity,"2015-05-01 00:00:00+00"), empty}`,
+			want:   []timespanset.Span{{Start: timespanset.MinTime, End: time.Date(2015, time.May, 1, 0, 0, 0, 0, time.UTC)}, {Start: at(1, 0).Add(500*time.Millisecond + time.Microsecond), End: timespanset.MaxTime}},
+			stored: `{(,"2015-05-01 00:00:00+00"),["2015-06-01 00:00:00.500001+00",)}`,
+		},
+	} {
+		var r TimeMultirange
+		if err := r.Scan(tt.wire); err != nil {
+			t.Errorf("Scan(%q) failed: %v", tt.wire, err)
+			continue
+		}
+		got := spans(r.Set)
+		ok := len(got) == len(tt.want)
+		for i := 0; ok && i < len(got); i++ {
+			ok = got[i].Start.Equal(tt.want[i].Start) && got[i].End.Equal(tt.want[i].End)
+		}
+		if !ok {
+			t.Errorf("Scan(%q) = %v, want %v", tt.wire, got, tt.want)
+		}
+		if got, err := r.Value(); err != nil || got != tt.stored {
+			t.Errorf("Scan(%q).Value() = %v, %v, want %s", tt.wire, got, err, tt.stored)
+		}
+
+		// The stored value scans back to the same set.
+		var again TimeMultirange
+		if err := again.Scan(tt.stored); err != nil {
+			t.Errorf("Scan(%q) failed: %v", tt.stored, err)
+		} else if got, _ := again.Value(); got != tt.stored {
+			t.Errorf("Scan(%q).Value() = %v, want the same value", tt.stored, got)
+		}
+	}
+
+	// Exclusive lower and inclusive upper bounds round-trip at the microsecond
+	// resolution of timestamptz.
+	var tr TimeRange
+	wire := `("2020-01-01 00:00:00+00","2020-01-02 00:00:00+00"]`
+	if err := tr.Scan(wire); err != nil {
+		t.Fatalf("Scan(%q) failed: %v", wire, err)
+	}
+	if got, err := tr.Value(); err != nil || got != `["2020-01-01 00:00:00.000001+00","2020-01-02 00:00:00.000001+00")` {
+		t.Errorf("Scan(%q).Value() = %v, %v", wire, got, err)
+	}
+
+	s := timespanset.Empty()
+	s.Insert(at(1, 0), at(1, 1).Add(time.Nanosecond))
+	if got, err := (TimeMultirange{s}).Value(); err == nil {
+		t.Errorf("Value() of a span ending at a nanosecond = %v, want an error", got)
+	}
+
+	var r TimeRange
+	if err := r.Scan(`[2015-06-01,2015-06-02)`); err == nil {
+		t.Errorf("Scan() of a date range succeeded, want an error")
+	}
+	if err := r.Scan(`(,)`); err != nil {
+		t.Fatalf("Scan((,)) failed: %v", err)
+	}
+	if got, err := r.Value(); err != nil || got != "(,)" {
+		t.Errorf("Value() = %v, %v, want (,)", got, err)
+	}
+}
@@ -0,0 +1,201 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pgrange
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/go-intervals/timespanset"
+)
+
+// TimeRange adapts a timespanset.Set to a PostgreSQL tstzrange column. The
+// set must contain at most one span to be stored.
+//
+// Scanning NULL sets Set to nil, and a nil Set is stored as NULL. Unbounded
+// and infinite bounds are scanned as timespanset.MinTime and
+// timespanset.MaxTime, and those times are stored as unbounded. Exclusive
+// lower and inclusive upper bounds are moved forward by a microsecond, the
+// resolution of timestamptz, so that the span includes its start and excludes
+// its end. Times with a finer resolution can not be stored.
+type TimeRange struct {
+	Set *timespanset.Set
+}
+
+// TimeMultirange adapts a timespanset.Set to a PostgreSQL tstzmultirange
+// column. It handles NULL and bounds as TimeRange does.
+type TimeMultirange struct {
+	Set *timespanset.Set
+}
+
+var (
+	_ sql.Scanner   = (*TimeRange)(nil)
+	_ driver.Valuer = TimeRange{}
+	_ sql.Scanner   = (*TimeMultirange)(nil)
+	_ driver.Valuer = TimeMultirange{}
+)
+
+// Scan implements sql.Scanner. It accepts a range or a multirange.
+func (r *TimeRange) Scan(src interface{}) error {
+	s, err := scanTimes(src)
+	if err == nil {
+		r.Set = s
+	}
+	return err
+}
+
+// Value implements driver.Valuer. It returns an error if the set contains
+// more than one span.
+func (r TimeRange) Value() (driver.Value, error) {
+	if r.Set == nil {
+		return nil, nil
+	}
+	ranges, err := timeRanges(r.Set)
+	if err != nil {
+		return nil, err
+	}
+	switch len(ranges) {
+	case 0:
+		return "empty", nil
+	case 1:
+		return ranges[0], nil
+	}
+	return nil, fmt.Errorf("pgrange: a tstzrange can not hold %d spans: %s", len(ranges), r.Set)
+}
+
+// Scan implements sql.Scanner. It accepts a range or a multirange.
+func (r *TimeMultirange) Scan(src interface{}) error {
+	s, err := scanTimes(src)
+	if err == nil {
+		r.Set = s
+	}
+	return err
+}
+
+// Value implements driver.Valuer.
+func (r TimeMultirange) Value() (driver.Value, error) {
+	if r.Set == nil {
+		return nil, nil
+	}
+	ranges, err := timeRanges(r.Set)
+	if err != nil {
+		return nil, err
+	}
+	return formatMultirange(ranges), nil
+}
+
+func scanTimes(src interface{}) (*timespanset.Set, error) {
+	if src == nil {
+		return nil, nil
+	}
+	if t, ok := src.(time.Time); ok {
+		return nil, fmt.Errorf("pgrange: can not scan a single time %s", t)
+	}
+	text, err := scanText(src)
+	if err != nil {
+		return nil, err
+	}
+	ranges, err := parseRanges(text)
+	if err != nil {
+		return nil, err
+	}
+	result := timespanset.Empty()
+	for _, r := range ranges {
+		if r.empty {
+			continue
+		}
+		start, err := parseTimeBound(r.lower, timespanset.MinTime)
+		if err != nil {
+			return nil, err
+		}
+		end, err := parseTimeBound(r.upper, timespanset.MaxTime)
+		if err != nil {
+			return nil, err
+		}
+		if !r.lower.inclusive && !r.lower.unbounded && !start.Equal(timespanset.MinTime) {
+			start = start.Add(time.Microsecond)
+		}
+		if r.upper.inclusive && !r.upper.unbounded && !end.Equal(timespanset.MaxTime) {
+			end = end.Add(time.Microsecond)
+		}
+		if end.Before(start) {
+			return nil, fmt.Errorf("pgrange: range %q has upper bound before lower bound", text)
+		}
+		result.Insert(start, end)
+	}
+	return result, nil
+}
+
+// timeLayouts are the layouts of timestamptz values in the text
+// representation, with the UTC offset given in hours, minutes or seconds.
+var timeLayouts = []string{
+	"2006-01-02 15:04:05.999999999-07",
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999-07:00:00",
+	"2006-01-02T15:04:05.999999999Z07:00",
+}
+
+// parseTimeBound parses a bound of a tstzrange. Unbounded and infinite values
+// return open.
+func parseTimeBound(b bound, open time.Time) (time.Time, error) {
+	switch {
+	case b.unbounded:
+		return open, nil
+	case strings.EqualFold(b.text, "-infinity"):
+		return timespanset.MinTime, nil
+	case strings.EqualFold(b.text, "infinity"):
+		return timespanset.MaxTime, nil
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, b.text); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("pgrange: invalid timestamptz %q", b.text)
+}
+
+// timeRanges returns the text representation of each span of s. It returns an
+// error if a span has a bound that is not a whole number of microseconds,
+// since PostgreSQL would round it.
+func timeRanges(s *timespanset.Set) ([]string, error) {
+	var result []string
+	var err error
+	first, last := s.Extent()
+	s.IntervalsBetween(first, last, func(start, end time.Time) bool {
+		var lower, upper string
+		if lower, err = formatTimeBound(start, timespanset.MinTime); err != nil {
+			return false
+		}
+		if upper, err = formatTimeBound(end, timespanset.MaxTime); err != nil {
+			return false
+		}
+		result = append(result, formatRange(lower, upper))
+		return true
+	})
+	return result, err
+}
+
+func formatTimeBound(t, open time.Time) (string, error) {
+	if t.Equal(open) {
+		return "", nil
+	}
+	if t.Nanosecond()%int(time.Microsecond) != 0 {
+		return "", fmt.Errorf("pgrange: time %s is finer than the microsecond resolution of timestamptz", t)
+	}
+	return t.UTC().Format("2006-01-02 15:04:05.999999-07"), nil
+}
@@ -0,0 +1,290 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package recurring describes windows of wall-clock time that repeat every day
+// or every week, such as business hours, and expands them into concrete
+// timespanset.Sets.
+//
+// A Pattern divides its period into slots of a fixed resolution, and each
+// window is a modinterval.IntInterval over those slots, so windows such as
+// "22:00-02:00" or "Fri 18:00 - Mon 06:00" may wrap around the end of the
+// period.
+//
+// DISCLAIMER: This library is not yet stable, so expect breaking changes.
+package recurring
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/go-intervals/intset"
+	"github.com/google/go-intervals/modinterval"
+	"github.com/google/go-intervals/timespanset"
+)
+
+const (
+	// Day is the period of a daily pattern.
+	Day = 24 * time.Hour
+
+	// Week is the period of a weekly pattern. Weeks start on Sunday.
+	Week = 7 * Day
+)
+
+// Pattern is a set of windows of wall-clock time that recur with a period of
+// a day or a week. Offsets within the period are measured from midnight, or
+// from midnight on Sunday for weekly patterns.
+type Pattern struct {
+	period     time.Duration
+	resolution time.Duration
+	slots      *intset.Set
+}
+
+// Daily returns a new, empty pattern that repeats every day. Window boundaries
+// must be multiples of resolution, which must divide 24 hours evenly.
+func Daily(resolution time.Duration) *Pattern {
+	return newPattern(Day, resolution)
+}
+
+// Weekly returns a new, empty pattern that repeats every week. Window
+// boundaries must be multiples of resolution, which must divide 24 hours
+// evenly.
+func Weekly(resolution time.Duration) *Pattern {
+	return newPattern(Week, resolution)
+}
+
+func newPattern(period, resolution time.Duration) *Pattern {
+	if resolution <= 0 || Day%resolution != 0 {
+		panic(fmt.Errorf("resolution %s does not divide 24h", resolution))
+	}
+	return &Pattern{period, resolution, intset.Empty()}
+}
+
+// WeekOffset returns the offset of a wall-clock time on the given day from the
+// start of the week, for use with weekly patterns.
+func WeekOffset(day time.Weekday, hour, minute int) time.Duration {
+	return time.Duration(day)*Day + time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute
+}
+
+// Period returns Day or Week.
+func (p *Pattern) Period() time.Duration {
+	return p.period
+}
+
+// Resolution returns the length of a slot of the pattern.
+func (p *Pattern) Resolution() time.Duration {
+	return p.resolution
+}
+
+// Modulus returns the number of slots in the period of the pattern.
+func (p *Pattern) Modulus() modinterval.Modulus {
+	return modinterval.Modulus(p.period / p.resolution)
+}
+
+// Copy returns a copy of a pattern that may be mutated without affecting the
+// original.
+func (p *Pattern) Copy() *Pattern {
+	return &Pattern{p.period, p.resolution, p.slots.Copy()}
+}
+
+// ToWeekly returns a weekly pattern with the windows of a daily pattern
+// repeated on every day of the week. A weekly pattern is returned unchanged.
+func (p *Pattern) ToWeekly() *Pattern {
+	if p.period == Week {
+		return p
+	}
+	result := Weekly(p.resolution)
+	m := int64(p.Modulus())
+	p.slots.Intervals(func(lo, hi int64) bool {
+		for day := int64(0); day < 7; day++ {
+			result.slots.Insert(day*m+lo, day*m+hi)
+		}
+		return true
+	})
+	return result
+}
+
+func (p *Pattern) slot(offset time.Duration) int {
+	if offset%p.resolution != 0 {
+		panic(fmt.Errorf("offset %s is not a multiple of the resolution %s", offset, p.resolution))
+	}
+	return p.Modulus().ArrayOffset(int(offset / p.resolution))
+}
+
+// Insert adds the window from start up to end to the pattern. Offsets are
+// measured from the start of the period and are taken modulo the period, so a
+// window whose end is before its start wraps around, and a window whose end
+// equals its start covers the whole period. It panics if an offset is not a
+// multiple of the resolution.
+func (p *Pattern) Insert(start, end time.Duration) {
+	m := p.Modulus()
+	a, b := p.slot(start), p.slot(end)
+	size := m.IntervalSizeForward(a, b)
+	if size == 0 {
+		size = m.Int()
+	}
+	p.InsertInterval(modinterval.FromStartSizeInt(m, a, size))
+}
+
+// InsertInterval adds the slots of iv to the pattern. It panics if the modulus
+// of iv is not p.Modulus().
+func (p *Pattern) InsertInterval(iv modinterval.IntInterval) {
+	if iv.Modulus() != p.Modulus() {
+		panic(fmt.Errorf("interval modulus %d does not match pattern modulus %d", iv.Modulus(), p.Modulus()))
+	}
+	p.slots.Add(intset.FromRealIntIntervals(iv.RealIntervals()...))
+}
+
+func (p *Pattern) checkCompatible(b *Pattern) {
+	if p.period != b.period || p.resolution != b.resolution {
+		panic(fmt.Errorf("incompatible patterns with period %s and resolution %s, and period %s and resolution %s",
+			p.period, p.resolution, b.period, b.resolution))
+	}
+}
+
+// Add performs an in-place union of two patterns. It panics if the patterns
+// have different periods or resolutions; use ToWeekly to combine a daily
+// pattern with a weekly one.
+func (p *Pattern) Add(b *Pattern) {
+	p.checkCompatible(b)
+	p.slots.Add(b.slots)
+}
+
+// Sub performs an in-place subtraction of pattern b from pattern p. It panics
+// if the patterns have different periods or resolutions.
+func (p *Pattern) Sub(b *Pattern) {
+	p.checkCompatible(b)
+	p.slots.Sub(b.slots)
+}
+
+// Intersect performs an in-place intersection of patterns p and b. It panics
+// if the patterns have different periods or resolutions.
+func (p *Pattern) Intersect(b *Pattern) {
+	p.checkCompatible(b)
+	p.slots.Intersect(b.slots)
+}
+
+// Empty reports whether the pattern has no windows.
+func (p *Pattern) Empty() bool {
+	return p.slots.Empty()
+}
+
+// Intervals returns the windows of the pattern as modular intervals of slots,
+// in order of their start. A window that wraps around the end of the period is
+// returned as a single interval, last.
+func (p *Pattern) Intervals() []modinterval.IntInterval {
+	m := p.Modulus()
+	var result []modinterval.IntInterval
+	p.slots.Intervals(func(lo, hi int64) bool {
+		result = append(result, modinterval.FromStartSizeInt(m, int(lo), int(hi-lo)))
+		return true
+	})
+	if n := len(result); n > 1 && result[0].Start() == 0 && result[n-1].End() == 0 {
+		wrapped := modinterval.FromStartSizeInt(m, result[n-1].Start(), result[n-1].Size()+result[0].Size())
+		result = append(result[1:n-1], wrapped)
+	}
+	return result
+}
+
+// String returns a human readable version of the pattern, for example
+// "{22:00-02:00}" or "{Fri 18:00-Mon 06:00}".
+func (p *Pattern) String() string {
+	var strs []string
+	for _, iv := range p.Intervals() {
+		start := time.Duration(iv.Start()) * p.resolution
+		end := start + time.Duration(iv.Size())*p.resolution
+		strs = append(strs, fmt.Sprintf("%s-%s", p.formatOffset(start), p.formatOffset(end%p.period)))
+	}
+	return fmt.Sprintf("{%s}", strings.Join(strs, ", "))
+}
+
+func (p *Pattern) formatOffset(offset time.Duration) string {
+	s := time.Time{}.Add(offset % Day).Format("15:04")
+	if offset%time.Minute != 0 {
+		s = time.Time{}.Add(offset % Day).Format("15:04:05.999999999")
+	}
+	if p.period == Week {
+		s = time.Weekday(offset / Day).String()[:3] + " " + s
+	}
+	return s
+}
+
+// wallClock returns the wall-clock reading of t in its location as a time in
+// UTC, so that arithmetic on the result ignores daylight saving transitions.
+func wallClock(t time.Time) time.Time {
+	_, offset := t.Zone()
+	return t.UTC().Add(time.Duration(offset) * time.Second)
+}
+
+// periodStart returns the start of the period containing the wall-clock time w.
+func (p *Pattern) periodStart(w time.Time) time.Time {
+	start := w.Truncate(Day)
+	if p.period == Week {
+		start = start.Add(-time.Duration(start.Weekday()) * Day)
+	}
+	return start
+}
+
+// Contains reports whether the wall-clock time of t in its location falls
+// within a window of the pattern.
+func (p *Pattern) Contains(t time.Time) bool {
+	w := wallClock(t)
+	return p.slots.Contains(int64(w.Sub(p.periodStart(w)) / p.resolution))
+}
+
+// Expand returns the instants in [from, to) whose wall-clock time in loc falls
+// within a window of the pattern.
+//
+// Wall-clock times skipped by a daylight saving transition are not covered, so
+// a window of 01:00-03:00 lasts an hour on a day when clocks skip from 02:00 to
+// 03:00. Wall-clock times repeated by a transition are covered each time they
+// occur, so a window of 01:00-02:00 lasts two hours on a day when clocks fall
+// back from 02:00 to 01:00.
+func (p *Pattern) Expand(from, to time.Time, loc *time.Location) *timespanset.Set {
+	result := timespanset.Empty()
+	for t := from.In(loc); t.Before(to); {
+		// Within a zone the offset from UTC is fixed, so wall-clock time is an
+		// instant shifted by a constant.
+		_, offset := t.Zone()
+		shift := time.Duration(offset) * time.Second
+		end := to
+		if _, zoneEnd := t.ZoneBounds(); !zoneEnd.IsZero() && zoneEnd.Before(to) {
+			end = zoneEnd
+		}
+		p.expandWallClock(result, wallClock(t), end.UTC().Add(shift), shift)
+		t = end.In(loc)
+	}
+	return result
+}
+
+// expandWallClock adds to result the windows of the pattern that fall within
+// the wall-clock times [from, to), converted to instants by subtracting shift.
+func (p *Pattern) expandWallClock(result *timespanset.Set, from, to time.Time, shift time.Duration) {
+	for start := p.periodStart(from); start.Before(to); start = start.Add(p.period) {
+		p.slots.Intervals(func(lo, hi int64) bool {
+			a := start.Add(time.Duration(lo) * p.resolution)
+			b := start.Add(time.Duration(hi) * p.resolution)
+			if a.Before(from) {
+				a = from
+			}
+			if b.After(to) {
+				b = to
+			}
+			if a.Before(b) {
+				result.Insert(a.Add(-shift), b.Add(-shift))
+			}
+			return true
+		})
+	}
+}
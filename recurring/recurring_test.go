@@ -0,0 +1,144 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recurring
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-intervals/timespanset"
+)
+
+func tz() *time.Location {
+	x, err := time.LoadLocation("PST8PDT")
+	if err != nil {
+		panic(fmt.Errorf("timezone not available: %v", err))
+	}
+	return x
+}
+
+type span struct {
+	start, end string
+}
+
+func spans(s *timespanset.Set) []span {
+	var result []span
+	s.IntervalsBetween(time.Time{}, time.Date(3000, 1, 1, 0, 0, 0, 0, time.UTC), func(start, end time.Time) bool {
+		result = append(result, span{start.In(tz()).Format("Jan 2 15:04 MST"), end.In(tz()).Format("Jan 2 15:04 MST")})
+		return true
+	})
+	return result
+}
+
+func at(month time.Month, day, hour int) time.Time {
+	return time.Date(2026, month, day, hour, 0, 0, 0, tz())
+}
+
+func TestDailyWrapping(t *testing.T) {
+	p := Daily(30 * time.Minute)
+	p.Insert(22*time.Hour, 2*time.Hour)
+	if got, want := p.String(), "{22:00-02:00}"; got != want {
+		t.Errorf("String() = %s, want %s", got, want)
+	}
+	if ivs := p.Intervals(); len(ivs) != 1 || ivs[0].Start() != 44 || ivs[0].Size() != 8 {
+		t.Errorf("Intervals() = %v, want a single interval of 8 slots starting at 44", ivs)
+	}
+
+	got := spans(p.Expand(at(time.June, 1, 12), at(time.June, 3, 1), tz()))
+	want := []span{
+		{"Jun 1 22:00 PDT", "Jun 2 02:00 PDT"},
+		{"Jun 2 22:00 PDT", "Jun 3 01:00 PDT"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expand() = %v, want %v", got, want)
+	}
+
+	if !p.Contains(at(time.June, 5, 23)) || !p.Contains(at(time.June, 5, 1)) || p.Contains(at(time.June, 5, 2)) {
+		t.Errorf("Contains gave unexpected results for %s", p)
+	}
+}
+
+func TestDaylightSaving(t *testing.T) {
+	p := Daily(time.Hour)
+	p.Insert(time.Hour, 3*time.Hour)
+
+	// On March 8, clocks skip from 02:00 to 03:00.
+	got := spans(p.Expand(at(time.March, 8, 0), at(time.March, 9, 0), tz()))
+	want := []span{{"Mar 8 01:00 PST", "Mar 8 03:00 PDT"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expand() = %v, want %v", got, want)
+	}
+
+	// On November 1, clocks fall back from 02:00 to 01:00, so 01:00-02:00
+	// happens twice.
+	s := p.Expand(at(time.November, 1, 0), at(time.November, 2, 0), tz())
+	got = spans(s)
+	want = []span{{"Nov 1 01:00 PDT", "Nov 1 03:00 PST"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expand() = %v, want %v", got, want)
+	}
+	var total time.Duration
+	s.IntervalsBetween(at(time.November, 1, 0), at(time.November, 2, 0), func(start, end time.Time) bool {
+		total += end.Sub(start)
+		return true
+	})
+	if total != 3*time.Hour {
+		t.Errorf("Expand() covers %s, want 3h", total)
+	}
+}
+
+func TestWeekly(t *testing.T) {
+	weekend := Weekly(time.Hour)
+	weekend.Insert(WeekOffset(time.Friday, 18, 0), WeekOffset(time.Monday, 6, 0))
+	if got, want := weekend.String(), "{Fri 18:00-Mon 06:00}"; got != want {
+		t.Errorf("String() = %s, want %s", got, want)
+	}
+
+	// June 1, 2026 is a Monday.
+	got := spans(weekend.Expand(at(time.June, 1, 0), at(time.June, 15, 0), tz()))
+	want := []span{
+		{"Jun 1 00:00 PDT", "Jun 1 06:00 PDT"},
+		{"Jun 5 18:00 PDT", "Jun 8 06:00 PDT"},
+		{"Jun 12 18:00 PDT", "Jun 15 00:00 PDT"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expand() = %v, want %v", got, want)
+	}
+
+	nights := Daily(time.Hour)
+	nights.Insert(22*time.Hour, 2*time.Hour)
+	weeklyNights := nights.ToWeekly()
+
+	both := weeklyNights.Copy()
+	both.Intersect(weekend)
+	if got, want := both.String(), "{Sun 22:00-Mon 02:00, Fri 22:00-Sat 02:00, Sat 22:00-Sun 02:00}"; got != want {
+		t.Errorf("Intersect() = %s, want %s", got, want)
+	}
+
+	either := weeklyNights.Copy()
+	either.Add(weekend)
+	if got, want := either.String(), "{Mon 22:00-Tue 02:00, Tue 22:00-Wed 02:00, Wed 22:00-Thu 02:00, Thu 22:00-Fri 02:00, Fri 18:00-Mon 06:00}"; got != want {
+		t.Errorf("Add() = %s, want %s", got, want)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Add() of a daily and a weekly pattern did not panic")
+		}
+	}()
+	nights.Add(weekend)
+}
@@ -0,0 +1,246 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package runeset is a finite set implementation for ranges of Unicode code
+// points, with conversion to and from *unicode.RangeTable.
+//
+// Like unicode.Range16 and unicode.Range32, this package describes ranges by
+// their lowest and highest runes, both inclusive.
+//
+// DISCLAIMER: This library is not yet stable, so expect breaking changes.
+package runeset
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/google/go-intervals/intset"
+)
+
+// Set is a finite set of runes. Functions are provided for iterating over the
+// ranges of the set and performing set operations (intersection, union,
+// subtraction).
+type Set struct {
+	set *intset.Set
+}
+
+// Empty returns a new, empty Set.
+func Empty() *Set {
+	return &Set{intset.Empty()}
+}
+
+// Of returns a set containing the given runes.
+func Of(runes ...rune) *Set {
+	s := Empty()
+	for _, r := range runes {
+		s.Insert(r)
+	}
+	return s
+}
+
+// FromTable returns a set containing the union of the runes of the given
+// tables.
+func FromTable(tables ...*unicode.RangeTable) *Set {
+	s := Empty()
+	for _, t := range tables {
+		for _, r := range t.R16 {
+			s.insertStride(rune(r.Lo), rune(r.Hi), rune(r.Stride))
+		}
+		for _, r := range t.R32 {
+			s.insertStride(rune(r.Lo), rune(r.Hi), rune(r.Stride))
+		}
+	}
+	return s
+}
+
+// FromName returns a set containing the runes of the named Unicode category
+// (such as "Lu" or "L"), script (such as "Latin") or property (such as
+// "White_Space"), as listed in unicode.Categories, unicode.Scripts and
+// unicode.Properties.
+func FromName(name string) (*Set, error) {
+	for _, tables := range []map[string]*unicode.RangeTable{unicode.Categories, unicode.Scripts, unicode.Properties} {
+		if t, ok := tables[name]; ok {
+			return FromTable(t), nil
+		}
+	}
+	return nil, fmt.Errorf("runeset: unknown Unicode category, script or property %q", name)
+}
+
+func (s *Set) insertStride(lo, hi, stride rune) {
+	if stride == 1 {
+		s.InsertRange(lo, hi)
+		return
+	}
+	for r := lo; r <= hi; r += stride {
+		s.Insert(r)
+	}
+}
+
+// Table returns a *unicode.RangeTable containing the runes of the set. Ranges
+// ending at or below 0xFFFF are stored in R16 and the rest in R32; a range
+// crossing that boundary is split. Every entry has a stride of 1.
+func (s *Set) Table() *unicode.RangeTable {
+	t := &unicode.RangeTable{}
+	s.Ranges(func(lo, hi rune) bool {
+		if lo <= 0xFFFF {
+			hi16 := hi
+			if hi16 > 0xFFFF {
+				hi16 = 0xFFFF
+			}
+			t.R16 = append(t.R16, unicode.Range16{Lo: uint16(lo), Hi: uint16(hi16), Stride: 1})
+			if hi16 <= unicode.MaxLatin1 {
+				t.LatinOffset++
+			}
+			lo = hi16 + 1
+		}
+		if lo <= hi {
+			t.R32 = append(t.R32, unicode.Range32{Lo: uint32(lo), Hi: uint32(hi), Stride: 1})
+		}
+		return true
+	})
+	return t
+}
+
+// String returns a human readable version of the set, for example
+// "{U+0041-U+005A, U+0061}".
+func (s *Set) String() string {
+	var strs []string
+	s.Ranges(func(lo, hi rune) bool {
+		strs = append(strs, Range{lo, hi}.String())
+		return true
+	})
+	return fmt.Sprintf("{%s}", strings.Join(strs, ", "))
+}
+
+// Copy returns a copy of a set that may be mutated without affecting the original.
+func (s *Set) Copy() *Set {
+	return &Set{s.set.Copy()}
+}
+
+// Insert adds r to the set. It returns a patch describing the change.
+func (s *Set) Insert(r rune) *Patch {
+	return s.InsertRange(r, r)
+}
+
+// InsertRange adds the runes from lo to hi inclusive to the set. It returns a
+// patch describing the runes that were not already in the set. It panics if
+// hi < lo or if either rune is outside [0, unicode.MaxRune].
+func (s *Set) InsertRange(lo, hi rune) *Patch {
+	if err := checkRange(lo, hi); err != nil {
+		panic(err)
+	}
+	return fromIntPatch(s.set.Insert(int64(lo), int64(hi)+1))
+}
+
+// checkRange returns an error if the runes from lo to hi inclusive are not a
+// valid range.
+func checkRange(lo, hi rune) error {
+	if lo < 0 || hi > unicode.MaxRune || hi < lo {
+		return fmt.Errorf("invalid rune range %U-%U", lo, hi)
+	}
+	return nil
+}
+
+// Add performs an in-place union of two sets. It returns a patch describing
+// the ranges that were actually added.
+func (s *Set) Add(b *Set) *Patch {
+	return fromIntPatch(s.set.Add(b.set))
+}
+
+// Sub performs an in-place subtraction of set b from set s. It returns a patch
+// describing the ranges that were actually removed.
+func (s *Set) Sub(b *Set) *Patch {
+	return fromIntPatch(s.set.Sub(b.set))
+}
+
+// Intersect performs an in-place intersection of sets s and b. It returns a
+// patch describing the ranges that were actually removed.
+func (s *Set) Intersect(b *Set) *Patch {
+	return fromIntPatch(s.set.Intersect(b.set))
+}
+
+// Empty reports whether the set has no members.
+func (s *Set) Empty() bool {
+	return s.set.Empty()
+}
+
+// Contains reports whether r is a member of the set.
+func (s *Set) Contains(r rune) bool {
+	return s.set.Contains(int64(r))
+}
+
+// Len returns the number of runes in the set.
+func (s *Set) Len() int {
+	return int(s.set.Cardinality())
+}
+
+// RangeReceiver is a function used for iterating over the ranges of a set. It
+// takes the lowest and highest (both inclusive) runes of a range and returns
+// true if the iteration should continue.
+type RangeReceiver func(lo, hi rune) bool
+
+// Ranges iterates over the ranges within the set in increasing order and calls
+// f with the lowest and highest rune of each. If f returns false, iteration
+// ceases.
+func (s *Set) Ranges(f RangeReceiver) {
+	s.set.Intervals(func(lo, hi int64) bool {
+		return f(rune(lo), rune(hi-1))
+	})
+}
+
+var (
+	foldableOnce sync.Once
+	foldable     *Set
+)
+
+// foldableRunes returns the set of runes that are equivalent to at least one
+// other rune under Unicode simple case folding.
+func foldableRunes() *Set {
+	foldableOnce.Do(func() {
+		foldable = Empty()
+		start := rune(-1)
+		for r := rune(0); r <= unicode.MaxRune+1; r++ {
+			folds := r <= unicode.MaxRune && unicode.SimpleFold(r) != r
+			switch {
+			case folds && start < 0:
+				start = r
+			case !folds && start >= 0:
+				foldable.InsertRange(start, r-1)
+				start = -1
+			}
+		}
+	})
+	return foldable
+}
+
+// FoldClosure returns a new set containing the runes of s and every rune
+// equivalent to one of them under Unicode simple case folding, as defined by
+// unicode.SimpleFold. For example, the closure of {k} is {K, k, U+212A KELVIN
+// SIGN}.
+func (s *Set) FoldClosure() *Set {
+	result := s.Copy()
+	folding := s.Copy()
+	folding.Intersect(foldableRunes())
+	folding.Ranges(func(lo, hi rune) bool {
+		for r := lo; r <= hi; r++ {
+			for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+				result.Insert(f)
+			}
+		}
+		return true
+	})
+	return result
+}
@@ -0,0 +1,152 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runeset
+
+import (
+	"reflect"
+	"testing"
+	"unicode"
+)
+
+func TestTableRoundTrip(t *testing.T) {
+	for name, table := range map[string]*unicode.RangeTable{
+		"Lu":          unicode.Lu,
+		"Latin":       unicode.Latin,
+		"Han":         unicode.Han,
+		"White_Space": unicode.White_Space,
+	} {
+		s := FromTable(table)
+		rt := s.Table()
+		// Every assigned rune of these tables is in the first four planes.
+		for r := rune(0); r < 0x40000; r++ {
+			want := unicode.Is(table, r)
+			if got := s.Contains(r); got != want {
+				t.Fatalf("%s: Contains(%U) = %v, want %v", name, r, got, want)
+			}
+			if got := unicode.Is(rt, r); got != want {
+				t.Fatalf("%s: unicode.Is(Table(), %U) = %v, want %v", name, r, got, want)
+			}
+		}
+		if got, want := FromTable(rt).String(), s.String(); got != want {
+			t.Errorf("%s: FromTable(Table()) = %s, want %s", name, got, want)
+		}
+	}
+}
+
+func TestTable(t *testing.T) {
+	s := Empty()
+	s.InsertRange('a', 'z')
+	s.InsertRange(0xFFF0, 0x10010)
+	s.Insert(0x1F600)
+
+	want := &unicode.RangeTable{
+		R16: []unicode.Range16{
+			{Lo: 'a', Hi: 'z', Stride: 1},
+			{Lo: 0xFFF0, Hi: 0xFFFF, Stride: 1},
+		},
+		R32: []unicode.Range32{
+			{Lo: 0x10000, Hi: 0x10010, Stride: 1},
+			{Lo: 0x1F600, Hi: 0x1F600, Stride: 1},
+		},
+		LatinOffset: 1,
+	}
+	if got := s.Table(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Table() = %+v, want %+v", got, want)
+	}
+	if got, want := s.String(), "{U+0061-U+007A, U+FFF0-U+10010, U+1F600}"; got != want {
+		t.Errorf("String() = %s, want %s", got, want)
+	}
+	if got, want := s.Len(), 26+0x21+1; got != want {
+		t.Errorf("Len() = %d, want %d", got, want)
+	}
+}
+
+func TestLetterMinusLatin(t *testing.T) {
+	letters, err := FromName("L")
+	if err != nil {
+		t.Fatal(err)
+	}
+	latin, err := FromName("Latin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	letters.Sub(latin)
+	for _, tt := range []struct {
+		r    rune
+		want bool
+	}{
+		{'a', false},
+		{'é', false},
+		{'λ', true},
+		{'ж', true},
+		{'中', true},
+		{'1', false},
+	} {
+		if got := letters.Contains(tt.r); got != tt.want {
+			t.Errorf("Contains(%q) = %v, want %v", tt.r, got, tt.want)
+		}
+	}
+	if _, err := FromName("Klingon"); err == nil {
+		t.Errorf("FromName(Klingon) = nil error, want error")
+	}
+}
+
+func TestPatches(t *testing.T) {
+	s := Empty()
+	if got, want := s.InsertRange('a', 'z'), (&Patch{Added: []Range{{'a', 'z'}}}); !reflect.DeepEqual(got, want) {
+		t.Errorf("InsertRange() = %+v, want %+v", got, want)
+	}
+	if got := s.Insert('q'); !got.IsEmpty() {
+		t.Errorf("Insert('q') = %+v, want an empty patch", got)
+	}
+	if got, want := s.Sub(Of('a', 'e', 'i', 'o', 'u')), (&Patch{Removed: []Range{{'a', 'a'}, {'e', 'e'}, {'i', 'i'}, {'o', 'o'}, {'u', 'u'}}}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Sub() = %+v, want %+v", got, want)
+	}
+	if got, want := s.Intersect(FromTable(unicode.ASCII_Hex_Digit)), (&Patch{Removed: []Range{{'g', 'h'}, {'j', 'n'}, {'p', 't'}, {'v', 'z'}}}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Intersect() = %+v, want %+v", got, want)
+	}
+	if got, want := s.Add(Of('a', 'A')), (&Patch{Added: []Range{{'A', 'A'}, {'a', 'a'}}}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Add() = %+v, want %+v", got, want)
+	}
+
+	old, updated := Of('x'), FromTable(unicode.Digit)
+	NewPatch(old, updated).Apply(old)
+	if got, want := old.String(), updated.String(); got != want {
+		t.Errorf("NewPatch(old, updated).Apply(old) = %s, want %s", got, want)
+	}
+}
+
+func TestFoldClosure(t *testing.T) {
+	for _, tt := range []struct {
+		in, want *Set
+	}{
+		{Of('k'), Of('K', 'k', 0x212A)},
+		{Of('s', '1'), Of('1', 'S', 's', 0x17F)},
+		{Of('Σ'), Of('Σ', 'σ', 'ς')},
+	} {
+		got := tt.in.FoldClosure()
+		if got.String() != tt.want.String() {
+			t.Errorf("FoldClosure(%v) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+
+	upper := FromTable(unicode.Upper)
+	closure := upper.FoldClosure()
+	for _, r := range "abcxyzäλж" {
+		if !closure.Contains(r) {
+			t.Errorf("FoldClosure(Upper) does not contain %q", r)
+		}
+	}
+}
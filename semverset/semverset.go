@@ -0,0 +1,98 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package semverset is a finite set implementation for ranges of semantic
+// versions, with a parser for common version constraint syntaxes.
+//
+// Versions are ordered by semver precedence, so 1.0.0-alpha < 1.0.0-alpha.1 <
+// 1.0.0-beta < 1.0.0. Unlike some package managers, a constraint such as
+// "<2.0.0" does match prereleases of 2.0.0; the caret, tilde and wildcard forms
+// exclude them by using an upper bound such as "<2.0.0-0".
+//
+// DISCLAIMER: This library is not yet stable, so expect breaking changes.
+package semverset
+
+import (
+	"github.com/google/go-intervals/intervalset"
+)
+
+// Set is a set of semantic versions represented as a union of version ranges.
+// Functions are provided for performing set operations (intersection, union,
+// subtraction).
+//
+// This is a version-specific implementation of intervalset.Set.
+type Set struct {
+	iset *intervalset.Set
+}
+
+// Empty returns a new set that matches no versions.
+func Empty() *Set {
+	return &Set{intervalset.EmptyV1(makeZero)}
+}
+
+// All returns a new set that matches every version.
+func All() *Set {
+	s := Empty()
+	s.insert(minVersion, bound{inf: true})
+	return s
+}
+
+func (s *Set) insert(lo Version, hi bound) {
+	r := newRange(lo, hi)
+	if r.IsZero() {
+		return
+	}
+	s.iset.Add(intervalset.NewSetV1([]intervalset.Interval{r}, makeZero))
+}
+
+// Copy returns a copy of a set that may be mutated without affecting the original.
+func (s *Set) Copy() *Set {
+	return &Set{s.iset.Copy()}
+}
+
+// Add performs an in-place union of two sets. It returns a patch describing
+// the ranges that were actually added.
+func (s *Set) Add(b *Set) *Patch {
+	return fromIntervalPatch(s.iset.Add(b.iset))
+}
+
+// Sub performs an in-place subtraction of set b from set s. It returns a patch
+// describing the ranges that were actually removed.
+func (s *Set) Sub(b *Set) *Patch {
+	return fromIntervalPatch(s.iset.Sub(b.iset))
+}
+
+// Intersect performs an in-place intersection of sets s and b. It returns a
+// patch describing the ranges that were actually removed.
+func (s *Set) Intersect(b *Set) *Patch {
+	return fromIntervalPatch(s.iset.Intersect(b.iset))
+}
+
+// Empty reports whether the set matches no versions, meaning the constraint
+// it was built from is unsatisfiable.
+func (s *Set) Empty() bool {
+	return s.iset.Extent().IsZero()
+}
+
+// Intersects reports whether some version is matched by both s and b.
+func (s *Set) Intersects(b *Set) bool {
+	c := s.Copy()
+	c.Intersect(b)
+	return !c.Empty()
+}
+
+// Contains reports whether v is matched by the set.
+func (s *Set) Contains(v Version) bool {
+	return s.iset.Contains(newRange(v, bound{v: successor(v)}))
+}
@@ -0,0 +1,221 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package semverset
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/go-intervals/intervalset"
+)
+
+// Parse returns the set of versions matched by a constraint.
+//
+// A constraint is a list of alternatives separated by "||". Each alternative is
+// a list of comparators separated by spaces or commas, all of which must match.
+// The following comparators are supported, where a version may be partial
+// ("1.2") or use "x", "X" or "*" wildcards ("1.2.x"):
+//
+//	1.2.3, =1.2.3    exactly 1.2.3
+//	1.2, 1.2.x       >=1.2.0 <1.3.0-0
+//	*, x, or empty   any version
+//	>1.2, >=1.2      greater than every 1.2.x, at least 1.2.0
+//	<1.2, <=1.2      less than 1.2.0-0, at most every 1.2.x
+//	!=1.2.3          any version except 1.2.3
+//	~1.2.3, ~>1.2.3  >=1.2.3 <1.3.0-0
+//	^1.2.3           >=1.2.3 <2.0.0-0
+//	^0.2.3           >=0.2.3 <0.3.0-0
+//	^0.0.3           >=0.0.3 <0.0.4-0
+//	1.2.3 - 2.3      >=1.2.3 <2.4.0-0
+//
+// A leading "v" on a version is ignored.
+func Parse(constraint string) (*Set, error) {
+	result := Empty()
+	for _, alt := range strings.Split(constraint, "||") {
+		s, err := parseAlternative(alt)
+		if err != nil {
+			return nil, fmt.Errorf("semverset: invalid constraint %q: %w", constraint, err)
+		}
+		result.Add(s)
+	}
+	return result, nil
+}
+
+// MustParse is like Parse but panics if the constraint can not be parsed.
+func MustParse(constraint string) *Set {
+	s, err := Parse(constraint)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// operators lists the comparator operators, longest first.
+var operators = []string{">=", "<=", "!=", "~>", ">", "<", "=", "^", "~"}
+
+func parseAlternative(alt string) (*Set, error) {
+	fields := strings.Fields(strings.ReplaceAll(alt, ",", " "))
+	if len(fields) == 3 && fields[1] == "-" {
+		lo, err := parseOperand(fields[0])
+		if err != nil {
+			return nil, err
+		}
+		hi, err := parseOperand(fields[2])
+		if err != nil {
+			return nil, err
+		}
+		s := Empty()
+		s.insert(lo.lower(), upper(hi.after()))
+		return s, nil
+	}
+
+	// Join operators separated from their versions by spaces, as in ">= 1.2".
+	var comparators []string
+	for i := 0; i < len(fields); i++ {
+		f := fields[i]
+		if isOperator(f) && i+1 < len(fields) {
+			f += fields[i+1]
+			i++
+		}
+		comparators = append(comparators, f)
+	}
+
+	result := All()
+	for _, c := range comparators {
+		s, err := parseComparator(c)
+		if err != nil {
+			return nil, err
+		}
+		result.Intersect(s)
+	}
+	return result, nil
+}
+
+func isOperator(text string) bool {
+	for _, op := range operators {
+		if text == op {
+			return true
+		}
+	}
+	return false
+}
+
+func parseOperand(text string) (partial, error) {
+	return parsePartial(strings.TrimPrefix(strings.TrimPrefix(text, "v"), "V"))
+}
+
+// upper converts the result of partial.after to an upper bound.
+func upper(v Version, ok bool) bound {
+	return bound{v: v, inf: !ok}
+}
+
+func parseComparator(c string) (*Set, error) {
+	op := ""
+	for _, o := range operators {
+		if strings.HasPrefix(c, o) {
+			op = o
+			break
+		}
+	}
+	p, err := parseOperand(c[len(op):])
+	if err != nil {
+		return nil, err
+	}
+
+	s := Empty()
+	switch op {
+	case "", "=":
+		s.insert(p.lower(), upper(p.after()))
+	case "!=":
+		s = All()
+		s.Sub(&Set{intervalset.NewSetV1([]intervalset.Interval{newRange(p.lower(), upper(p.after()))}, makeZero)})
+	case ">=":
+		s.insert(p.lower(), bound{inf: true})
+	case ">":
+		if v, ok := p.after(); ok {
+			s.insert(v, bound{inf: true})
+		}
+	case "<":
+		lo := p.lower()
+		if p.n < 3 {
+			// Exclude the prereleases of a partial version too.
+			lo.Prerelease = []string{"0"}
+		}
+		s.insert(minVersion, bound{v: lo})
+	case "<=":
+		s.insert(minVersion, upper(p.after()))
+	case "~", "~>":
+		q := p
+		if q.n == 3 {
+			q.n = 2
+		}
+		s.insert(p.lower(), upper(q.after()))
+	case "^":
+		// The upper bound increments the first non-zero component, or the
+		// last specified one if they are all zero.
+		q := p
+		switch {
+		case p.n >= 1 && p.version.Major > 0, p.n == 1:
+			q.n = 1
+		case p.n >= 2 && p.version.Minor > 0, p.n == 2:
+			q.n = 2
+		default:
+			q.version.Prerelease = nil
+		}
+		s.insert(p.lower(), upper(q.after()))
+	}
+	return s, nil
+}
+
+// String returns a normalized constraint matching exactly the versions of the
+// set, for example ">=1.2.0 <2.0.0-0 || >=3.1.0". Alternatives are in
+// increasing order and do not overlap. The empty set is rendered as "<0.0.0-0",
+// which matches no versions.
+func (s *Set) String() string {
+	var alts []string
+	s.iset.Intervals(func(x intervalset.Interval) bool {
+		alts = append(alts, rangeString(rangeOrPanic(x)))
+		return true
+	})
+	if len(alts) == 0 {
+		return "<" + minVersion.String()
+	}
+	return strings.Join(alts, " || ")
+}
+
+func rangeString(r *vrange) string {
+	if !r.hi.inf && Compare(successor(r.lo), r.hi.v) == 0 {
+		return r.lo.String()
+	}
+	var comparators []string
+	if Compare(r.lo, minVersion) != 0 {
+		if v, ok := predecessor(r.lo); ok {
+			comparators = append(comparators, ">"+v.String())
+		} else {
+			comparators = append(comparators, ">="+r.lo.String())
+		}
+	}
+	if !r.hi.inf {
+		if v, ok := predecessor(r.hi.v); ok {
+			comparators = append(comparators, "<="+v.String())
+		} else {
+			comparators = append(comparators, "<"+r.hi.v.String())
+		}
+	}
+	if len(comparators) == 0 {
+		return "*"
+	}
+	return strings.Join(comparators, " ")
+}
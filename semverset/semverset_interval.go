@@ -0,0 +1,153 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package semverset
+
+import (
+	"fmt"
+
+	"github.com/google/go-intervals/intervalset"
+)
+
+// bound is the exclusive upper bound of a version range. If inf is true, the
+// range has no upper bound and v is ignored.
+type bound struct {
+	v   Version
+	inf bool
+}
+
+func compareBound(a, b bound) int {
+	switch {
+	case a.inf && b.inf:
+		return 0
+	case a.inf:
+		return 1
+	case b.inf:
+		return -1
+	}
+	return Compare(a.v, b.v)
+}
+
+// vrange is a private implementation of intervalset.Interval for the versions
+// in [lo, hi).
+//
+// Inclusive upper bounds and exclusive lower bounds are expressed using the
+// successor of a version, the smallest version with higher precedence. Build
+// metadata is always stripped from the endpoints.
+type vrange struct {
+	lo Version
+	hi bound
+}
+
+func (r *vrange) String() string {
+	if r.hi.inf {
+		return fmt.Sprintf("[%s, inf)", r.lo)
+	}
+	return fmt.Sprintf("[%s, %s)", r.lo, r.hi.v)
+}
+
+func rangeOrPanic(i intervalset.Interval) *vrange {
+	r, ok := i.(*vrange)
+	if !ok {
+		panic(fmt.Errorf("interval must be a version range: %v", i))
+	}
+	return r
+}
+
+func makeZero() intervalset.Interval {
+	return &vrange{}
+}
+
+// newRange returns the range [lo, hi), or the zero range if it is empty.
+func newRange(lo Version, hi bound) *vrange {
+	lo.Build = ""
+	hi.v.Build = ""
+	if compareBound(bound{v: lo}, hi) >= 0 {
+		return &vrange{}
+	}
+	return &vrange{lo, hi}
+}
+
+func (r *vrange) IsZero() bool {
+	return compareBound(bound{v: r.lo}, r.hi) >= 0
+}
+
+func (r *vrange) Before(other intervalset.Interval) bool {
+	return compareBound(r.hi, bound{v: rangeOrPanic(other).lo}) <= 0
+}
+
+func maxVersion(a, b Version) Version {
+	if Compare(a, b) < 0 {
+		return b
+	}
+	return a
+}
+
+func minBound(a, b bound) bound {
+	if compareBound(a, b) < 0 {
+		return a
+	}
+	return b
+}
+
+func maxBound(a, b bound) bound {
+	if compareBound(a, b) < 0 {
+		return b
+	}
+	return a
+}
+
+func (r *vrange) intersect(b *vrange) *vrange {
+	return newRange(maxVersion(r.lo, b.lo), minBound(r.hi, b.hi))
+}
+
+func (r *vrange) Intersect(other intervalset.Interval) intervalset.Interval {
+	return r.intersect(rangeOrPanic(other))
+}
+
+func (r *vrange) Bisect(other intervalset.Interval) (intervalset.Interval, intervalset.Interval) {
+	b := rangeOrPanic(other)
+	intersection := r.intersect(b)
+	if intersection.IsZero() {
+		if r.Before(b) {
+			return r, &vrange{}
+		}
+		return &vrange{}, r
+	}
+	after := &vrange{}
+	if !intersection.hi.inf {
+		after = newRange(intersection.hi.v, r.hi)
+	}
+	return newRange(r.lo, bound{v: intersection.lo}), after
+}
+
+func (r *vrange) Adjoin(other intervalset.Interval) intervalset.Interval {
+	b := rangeOrPanic(other)
+	if compareBound(r.hi, bound{v: b.lo}) == 0 {
+		return &vrange{r.lo, b.hi}
+	}
+	if compareBound(b.hi, bound{v: r.lo}) == 0 {
+		return &vrange{b.lo, r.hi}
+	}
+	return &vrange{}
+}
+
+func (r *vrange) Encompass(other intervalset.Interval) intervalset.Interval {
+	b := rangeOrPanic(other)
+	lo := r.lo
+	if Compare(b.lo, lo) < 0 {
+		lo = b.lo
+	}
+	return &vrange{lo, maxBound(r.hi, b.hi)}
+}
@@ -0,0 +1,113 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package semverset
+
+import (
+	"fmt"
+
+	"github.com/google/go-intervals/intervalset"
+)
+
+// Range is a range of versions that includes Lo and excludes Hi. If Hi is nil,
+// the range has no upper bound.
+type Range struct {
+	Lo Version  `json:"lo"`
+	Hi *Version `json:"hi,omitempty"`
+}
+
+// String returns the range as a normalized constraint, such as
+// ">=1.2.0 <2.0.0-0".
+func (r Range) String() string {
+	return rangeString(r.vrange())
+}
+
+func (r Range) vrange() *vrange {
+	hi := bound{inf: true}
+	if r.Hi != nil {
+		hi = bound{v: *r.Hi}
+	}
+	return &vrange{r.Lo, hi}
+}
+
+// Patch is a set of edits that can be applied to a Set: Removed is subtracted
+// from the set, then Added is added to it. Patches may be serialized, for
+// example with encoding/json.
+type Patch struct {
+	Added   []Range `json:"added,omitempty"`
+	Removed []Range `json:"removed,omitempty"`
+}
+
+// NewPatch returns a patch that transforms oldSet into newSet.
+func NewPatch(oldSet, newSet *Set) *Patch {
+	return fromIntervalPatch(intervalset.NewPatch(oldSet.iset, newSet.iset))
+}
+
+// IsEmpty reports whether applying the patch has no effect on any set.
+func (p *Patch) IsEmpty() bool {
+	return len(p.Added) == 0 && len(p.Removed) == 0
+}
+
+// Apply destructively modifies s by applying the patch to it. It panics if Hi
+// is before Lo in a range of the patch.
+func (p *Patch) Apply(s *Set) {
+	p.intervalPatch().Apply(s.iset)
+}
+
+// Then returns a patch that is equivalent to applying p followed by next.
+func (p *Patch) Then(next *Patch) *Patch {
+	return fromIntervalPatch(p.intervalPatch().Then(next.intervalPatch()))
+}
+
+func (p *Patch) intervalPatch() *intervalset.Patch {
+	return &intervalset.Patch{
+		Added:   rangesToIntervals(p.Added),
+		Removed: rangesToIntervals(p.Removed),
+	}
+}
+
+func fromIntervalPatch(p *intervalset.Patch) *Patch {
+	return &Patch{
+		Added:   intervalsToRanges(p.Added),
+		Removed: intervalsToRanges(p.Removed),
+	}
+}
+
+func rangesToIntervals(ranges []Range) []intervalset.Interval {
+	var result []intervalset.Interval
+	for _, r := range ranges {
+		x := r.vrange()
+		if compareBound(bound{v: x.lo}, x.hi) > 0 {
+			panic(fmt.Errorf("range hi %s before lo %s", x.hi.v, x.lo))
+		}
+		if x = newRange(x.lo, x.hi); !x.IsZero() {
+			result = append(result, x)
+		}
+	}
+	return result
+}
+
+func intervalsToRanges(intervals []intervalset.Interval) []Range {
+	var result []Range
+	for _, x := range intervals {
+		r := rangeOrPanic(x)
+		var hi *Version
+		if !r.hi.inf {
+			v := r.hi.v
+			hi = &v
+		}
+		result = append(result, Range{r.lo, hi})
+	}
+	return result
+}
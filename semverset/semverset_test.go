@@ -0,0 +1,181 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package semverset
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	// In increasing order of precedence, from the semver specification.
+	ordered := []string{
+		"0.0.0-0",
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1-0",
+		"1.0.1",
+		"2.0.0",
+		"2.1.0",
+		"2.1.1",
+	}
+	for i, a := range ordered {
+		for j, b := range ordered {
+			want := 0
+			if i < j {
+				want = -1
+			} else if i > j {
+				want = 1
+			}
+			if got := Compare(MustParseVersion(a), MustParseVersion(b)); got != want {
+				t.Errorf("Compare(%s, %s) = %d, want %d", a, b, got, want)
+			}
+		}
+	}
+	if Compare(MustParseVersion("1.0.0+build.1"), MustParseVersion("v1.0.0+build.2")) != 0 {
+		t.Errorf("Compare() does not ignore build metadata")
+	}
+	for _, text := range []string{"1.2", "1.2.3.4", "01.2.3", "1.2.3-01", "1.2.3-", "1.2.3+", "1.2.3-a..b", "1.x.3"} {
+		if _, err := ParseVersion(text); err == nil {
+			t.Errorf("ParseVersion(%q) = nil error, want error", text)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	for _, tt := range []struct {
+		constraint, want string
+	}{
+		{"", "*"},
+		{"*", "*"},
+		{"1.2.3", "1.2.3"},
+		{"=v1.2.3+build", "1.2.3"},
+		{"1.2", ">=1.2.0 <1.3.0-0"},
+		{"1.x", ">=1.0.0 <2.0.0-0"},
+		{">1.2.3", ">1.2.3"},
+		{">1.2", ">=1.3.0-0"},
+		{"> 1.2.3-beta", ">1.2.3-beta"},
+		{"<1.2", "<1.2.0-0"},
+		{"<=1.2", "<1.3.0-0"},
+		{"!=1.2.3", "<1.2.3 || >1.2.3"},
+		{"~1.2.3", ">=1.2.3 <1.3.0-0"},
+		{"~>1.2", ">=1.2.0 <1.3.0-0"},
+		{"~1", ">=1.0.0 <2.0.0-0"},
+		{"^1.2.3", ">=1.2.3 <2.0.0-0"},
+		{"^0.2.3", ">=0.2.3 <0.3.0-0"},
+		{"^0.0.3", "0.0.3"},
+		{"^0.0.3-beta", ">=0.0.3-beta <=0.0.3"},
+		{"^0.0", ">=0.0.0 <0.1.0-0"},
+		{"^0.x", ">=0.0.0 <1.0.0-0"},
+		{"1.2.3 - 2.3", ">=1.2.3 <2.4.0-0"},
+		{"1.2 - 2.3.4", ">=1.2.0 <=2.3.4"},
+		{">=1.2.0 <2.0.0 || ^3.1", ">=1.2.0 <2.0.0 || >=3.1.0 <4.0.0-0"},
+		{">=1.2.0, <2.0.0 || ^1.5", ">=1.2.0 <2.0.0"},
+		{"^1 || ^2", ">=1.0.0 <2.0.0-0 || >=2.0.0 <3.0.0-0"},
+		{"^1 || >=2.0.0-0 <3", ">=1.0.0 <3.0.0-0"},
+		{">=2 <1", "<0.0.0-0"},
+	} {
+		s, err := Parse(tt.constraint)
+		if err != nil {
+			t.Errorf("Parse(%q) = %v", tt.constraint, err)
+			continue
+		}
+		if got := s.String(); got != tt.want {
+			t.Errorf("Parse(%q) = %q, want %q", tt.constraint, got, tt.want)
+		}
+		// The normalized form must parse back to the same set.
+		if got := MustParse(s.String()).String(); got != tt.want {
+			t.Errorf("Parse(%q) = %q, want %q", s.String(), got, tt.want)
+		}
+	}
+	for _, constraint := range []string{"1.2.3.4", ">=", "^1.2.3 - 2", "1.2 - ", "=>1.2", "1.2.3 ||| 2"} {
+		if _, err := Parse(constraint); err == nil {
+			t.Errorf("Parse(%q) = nil error, want error", constraint)
+		}
+	}
+}
+
+func TestContainsAndIntersects(t *testing.T) {
+	s := MustParse(">=1.2.0 <2.0.0 || ^3.1")
+	for _, tt := range []struct {
+		version string
+		want    bool
+	}{
+		{"1.1.9", false},
+		{"1.2.0-rc.1", false},
+		{"1.2.0", true},
+		{"1.9.9+build", true},
+		{"2.0.0-alpha", true},
+		{"2.0.0", false},
+		{"3.1.0", true},
+		{"3.9.9", true},
+		{"4.0.0-alpha", false},
+	} {
+		if got := s.Contains(MustParseVersion(tt.version)); got != tt.want {
+			t.Errorf("Contains(%s) = %v, want %v", tt.version, got, tt.want)
+		}
+	}
+
+	if !s.Intersects(MustParse("~1.4")) || s.Intersects(MustParse(">=2.0.0 <3.1.0")) {
+		t.Errorf("Intersects gave unexpected results for %v", s)
+	}
+
+	s.Intersect(MustParse("<3.5 || 1.5.x"))
+	if got, want := s.String(), ">=1.2.0 <2.0.0 || >=3.1.0 <3.5.0-0"; got != want {
+		t.Errorf("Intersect() = %q, want %q", got, want)
+	}
+	s.Sub(MustParse("1.x"))
+	if got, want := s.String(), ">=2.0.0-0 <2.0.0 || >=3.1.0 <3.5.0-0"; got != want {
+		t.Errorf("Sub() = %q, want %q", got, want)
+	}
+	if !Empty().Empty() || All().Empty() {
+		t.Errorf("Empty() and All() gave unexpected results")
+	}
+}
+
+func TestPatches(t *testing.T) {
+	rangeStrings := func(ranges []Range) string {
+		var strs []string
+		for _, r := range ranges {
+			strs = append(strs, r.String())
+		}
+		return strings.Join(strs, " || ")
+	}
+	s := MustParse(">=1.0.0 <2.0.0")
+	p := s.Add(MustParse(">=1.5.0 <3.0.0"))
+	if got, want := rangeStrings(p.Added), ">=2.0.0 <3.0.0"; got != want || len(p.Removed) != 0 {
+		t.Errorf("Add() = added %q, removed %v, want added %q", got, p.Removed, want)
+	}
+	p = s.Sub(MustParse("^1.2"))
+	if got, want := rangeStrings(p.Removed), ">=1.2.0 <2.0.0-0"; got != want || len(p.Added) != 0 {
+		t.Errorf("Sub() = removed %q, added %v, want removed %q", got, p.Added, want)
+	}
+	p = s.Intersect(MustParse(">=2.5.0"))
+	if got, want := rangeStrings(p.Removed), ">=1.0.0 <1.2.0 || >=2.0.0-0 <2.5.0"; got != want {
+		t.Errorf("Intersect() = removed %q, want %q", got, want)
+	}
+
+	old, updated := MustParse("^1.0.0"), MustParse(">=1.5.0")
+	NewPatch(old, updated).Apply(old)
+	if got, want := old.String(), updated.String(); got != want {
+		t.Errorf("NewPatch(old, updated).Apply(old) = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,254 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package semverset
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is a semantic version as defined by https://semver.org/.
+type Version struct {
+	Major, Minor, Patch uint64
+
+	// Prerelease holds the dot-separated prerelease identifiers, such as
+	// ["beta", "2"] for 1.0.0-beta.2. It is empty for a release.
+	Prerelease []string
+
+	// Build holds the build metadata, which is ignored when comparing
+	// versions.
+	Build string
+}
+
+// minVersion is the smallest version, 0.0.0-0.
+var minVersion = Version{Prerelease: []string{"0"}}
+
+// ParseVersion parses a version such as "1.2.3", "1.2.3-rc.1" or
+// "1.2.3+build.5". A leading "v" is accepted.
+func ParseVersion(text string) (Version, error) {
+	p, err := parsePartial(strings.TrimPrefix(strings.TrimPrefix(text, "v"), "V"))
+	if err != nil {
+		return Version{}, err
+	}
+	if p.n != 3 {
+		return Version{}, fmt.Errorf("semverset: incomplete version %q", text)
+	}
+	return p.version, nil
+}
+
+// MustParseVersion is like ParseVersion but panics if the version can not be
+// parsed.
+func MustParseVersion(text string) Version {
+	v, err := ParseVersion(text)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// String returns the version in semver format, without a "v" prefix.
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if len(v.Prerelease) > 0 {
+		s += "-" + strings.Join(v.Prerelease, ".")
+	}
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+	return s
+}
+
+// Compare returns -1, 0 or 1 if a has lower, equal or higher precedence than b
+// according to the semver specification. Build metadata is ignored, and a
+// prerelease has lower precedence than the associated release.
+func Compare(a, b Version) int {
+	if c := compareUint(a.Major, b.Major); c != 0 {
+		return c
+	}
+	if c := compareUint(a.Minor, b.Minor); c != 0 {
+		return c
+	}
+	if c := compareUint(a.Patch, b.Patch); c != 0 {
+		return c
+	}
+	switch {
+	case len(a.Prerelease) == 0 && len(b.Prerelease) == 0:
+		return 0
+	case len(a.Prerelease) == 0:
+		return 1
+	case len(b.Prerelease) == 0:
+		return -1
+	}
+	for i := 0; i < len(a.Prerelease) && i < len(b.Prerelease); i++ {
+		if c := compareIdentifier(a.Prerelease[i], b.Prerelease[i]); c != 0 {
+			return c
+		}
+	}
+	return compareUint(uint64(len(a.Prerelease)), uint64(len(b.Prerelease)))
+}
+
+func compareUint(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// compareIdentifier compares prerelease identifiers. Numeric identifiers are
+// compared numerically and have lower precedence than alphanumeric ones.
+func compareIdentifier(a, b string) int {
+	an, bn := isNumeric(a), isNumeric(b)
+	switch {
+	case an && bn:
+		// Numeric identifiers have no leading zeros.
+		if c := compareUint(uint64(len(a)), uint64(len(b))); c != 0 {
+			return c
+		}
+	case an:
+		return -1
+	case bn:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func isNumeric(id string) bool {
+	for _, c := range id {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return id != ""
+}
+
+// successor returns the smallest version with higher precedence than v.
+func successor(v Version) Version {
+	if len(v.Prerelease) == 0 {
+		return Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch + 1, Prerelease: []string{"0"}}
+	}
+	pre := append(append([]string(nil), v.Prerelease...), "0")
+	return Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch, Prerelease: pre}
+}
+
+// predecessor returns the version whose successor is v. The second return
+// value is false if there is no such version.
+func predecessor(v Version) (Version, bool) {
+	n := len(v.Prerelease)
+	if n == 0 || v.Prerelease[n-1] != "0" {
+		return Version{}, false
+	}
+	if n > 1 {
+		return Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch, Prerelease: v.Prerelease[:n-1]}, true
+	}
+	if v.Patch == 0 {
+		return Version{}, false
+	}
+	return Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch - 1}, true
+}
+
+// partial is a possibly incomplete version such as "1.2", "1.x" or "*". Only
+// the first n numeric components are specified, and Prerelease may only be set
+// if n is 3.
+type partial struct {
+	version Version
+	n       int
+}
+
+func parsePartial(text string) (partial, error) {
+	invalid := fmt.Errorf("semverset: invalid version %q", text)
+	core, build, hasBuild := strings.Cut(text, "+")
+	core, pre, hasPre := strings.Cut(core, "-")
+	var p partial
+	parts := strings.Split(core, ".")
+	fields := [3]*uint64{&p.version.Major, &p.version.Minor, &p.version.Patch}
+	if len(parts) > 3 {
+		return partial{}, invalid
+	}
+	for i, s := range parts {
+		if s == "x" || s == "X" || s == "*" {
+			break
+		}
+		if !isNumeric(s) || (len(s) > 1 && s[0] == '0') {
+			return partial{}, invalid
+		}
+		n, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return partial{}, invalid
+		}
+		*fields[i] = n
+		p.n++
+	}
+	for _, s := range parts[p.n:] {
+		if s != "x" && s != "X" && s != "*" {
+			return partial{}, invalid
+		}
+	}
+	if hasPre {
+		if p.n != 3 {
+			return partial{}, invalid
+		}
+		for _, id := range strings.Split(pre, ".") {
+			if !validIdentifier(id) || (isNumeric(id) && len(id) > 1 && id[0] == '0') {
+				return partial{}, invalid
+			}
+			p.version.Prerelease = append(p.version.Prerelease, id)
+		}
+	}
+	if hasBuild {
+		for _, id := range strings.Split(build, ".") {
+			if !validIdentifier(id) {
+				return partial{}, invalid
+			}
+		}
+		p.version.Build = build
+	}
+	return p, nil
+}
+
+func validIdentifier(id string) bool {
+	for _, c := range id {
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '-') {
+			return false
+		}
+	}
+	return id != ""
+}
+
+// lower returns the smallest version matched by p.
+func (p partial) lower() Version {
+	if p.n == 0 {
+		return minVersion
+	}
+	return Version{Major: p.version.Major, Minor: p.version.Minor, Patch: p.version.Patch, Prerelease: p.version.Prerelease}
+}
+
+// after returns the smallest version greater than every version matched by
+// p. The second return value is false if there is no such version.
+func (p partial) after() (Version, bool) {
+	v := p.version
+	switch p.n {
+	case 0:
+		return Version{}, false
+	case 1:
+		return Version{Major: v.Major + 1, Prerelease: []string{"0"}}, true
+	case 2:
+		return Version{Major: v.Major, Minor: v.Minor + 1, Prerelease: []string{"0"}}, true
+	}
+	return successor(p.lower()), true
+}
@@ -0,0 +1,284 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package series provides step functions of time, such as the number of
+// on-call engineers over time or a price over time, and converts between step
+// functions and timespanset.Sets.
+//
+// DISCLAIMER: This library is not yet stable, so expect breaking changes.
+package series
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-intervals/timespanset"
+)
+
+// step is a point at which a step function changes to a new value.
+type step struct {
+	at    time.Time
+	value float64
+}
+
+// StepFunction is a piecewise-constant function of time. It has an initial
+// value up to its first step, and changes value at each step. Steps are kept
+// in increasing order of time, and consecutive steps always have different
+// values.
+//
+// StepFunctions are immutable: operations return new functions. The zero
+// value is the constant function 0.
+type StepFunction struct {
+	initial float64
+	steps   []step
+}
+
+// Constant returns the function whose value is v at all times.
+func Constant(v float64) *StepFunction {
+	return &StepFunction{initial: v}
+}
+
+// builder accumulates the steps of a function in increasing order of time.
+type builder struct {
+	f *StepFunction
+}
+
+func newBuilder(initial float64) *builder {
+	return &builder{&StepFunction{initial: initial}}
+}
+
+// last returns the value of the function after its last step.
+func (b *builder) last() float64 {
+	if n := len(b.f.steps); n > 0 {
+		return b.f.steps[n-1].value
+	}
+	return b.f.initial
+}
+
+// push sets the value of the function from t onwards. t must not be before
+// the last step.
+func (b *builder) push(t time.Time, v float64) {
+	if n := len(b.f.steps); n > 0 && b.f.steps[n-1].at.Equal(t) {
+		b.f.steps = b.f.steps[:n-1]
+	}
+	if v != b.last() {
+		b.f.steps = append(b.f.steps, step{t, v})
+	}
+}
+
+// WeightedSpan is a time span with a weight, such as the shift of an on-call
+// engineer with weight 1.
+type WeightedSpan struct {
+	Start, End time.Time
+	Weight     float64
+}
+
+// FromWeighted returns the function whose value at each time is the sum of
+// the weights of the spans containing that time. It panics if a span ends
+// before it starts.
+//
+// Each span is a function of its own, and the functions are summed in pairs
+// with Add, so the value is exactly zero where no spans are active.
+func FromWeighted(spans ...WeightedSpan) *StepFunction {
+	var fs []*StepFunction
+	for _, s := range spans {
+		if s.End.Before(s.Start) {
+			panic(fmt.Errorf("end %s before start %s", s.End, s.Start))
+		}
+		if s.Start.Equal(s.End) {
+			continue
+		}
+		b := newBuilder(0)
+		b.push(s.Start, s.Weight)
+		b.push(s.End, 0)
+		fs = append(fs, b.f)
+	}
+	if len(fs) == 0 {
+		return &StepFunction{}
+	}
+	for len(fs) > 1 {
+		var sums []*StepFunction
+		for i := 0; i < len(fs); i += 2 {
+			if i+1 == len(fs) {
+				sums = append(sums, fs[i])
+			} else {
+				sums = append(sums, Add(fs[i], fs[i+1]))
+			}
+		}
+		fs = sums
+	}
+	return fs[0]
+}
+
+// FromSet returns the function whose value is v within the spans of s and 0
+// elsewhere.
+func FromSet(s *timespanset.Set, v float64) *StepFunction {
+	b := newBuilder(0)
+	first, last := s.Extent()
+	s.IntervalsBetween(first, last, func(start, end time.Time) bool {
+		b.push(start, v)
+		b.push(end, 0)
+		return true
+	})
+	return b.f
+}
+
+// At returns the value of the function at t.
+func (f *StepFunction) At(t time.Time) float64 {
+	i := sort.Search(len(f.steps), func(i int) bool { return f.steps[i].at.After(t) })
+	if i == 0 {
+		return f.initial
+	}
+	return f.steps[i-1].value
+}
+
+// String returns a human readable version of the function.
+func (f *StepFunction) String() string {
+	parts := []string{fmt.Sprintf("-inf: %v", f.initial)}
+	for _, s := range f.steps {
+		parts = append(parts, fmt.Sprintf("%s: %v", s.at.Format(time.RFC3339Nano), s.value))
+	}
+	return "{" + strings.Join(parts, ", ") + "}"
+}
+
+// combine returns the function whose value at each time is op applied to the
+// values of a and b at that time. The time between the first and last steps of
+// a and b is partitioned at every step with timespanset.Set.SplitAt, and op is
+// applied once per piece.
+func combine(a, b *StepFunction, op func(x, y float64) float64) *StepFunction {
+	result := newBuilder(op(a.initial, b.initial))
+	var points []time.Time
+	for _, f := range []*StepFunction{a, b} {
+		for _, s := range f.steps {
+			points = append(points, s.at)
+		}
+	}
+	if len(points) == 0 {
+		return result.f
+	}
+	first, last := points[0], points[0]
+	for _, t := range points {
+		if t.Before(first) {
+			first = t
+		}
+		if t.After(last) {
+			last = t
+		}
+	}
+	domain := timespanset.Empty()
+	domain.Insert(first, last)
+	for _, piece := range domain.SplitAt(points...) {
+		// Pieces between equal points are empty.
+		if start, end := piece.Extent(); start.Before(end) {
+			result.push(start, op(a.At(start), b.At(start)))
+		}
+	}
+	result.push(last, op(a.At(last), b.At(last)))
+	return result.f
+}
+
+// Add returns the function a + b.
+func Add(a, b *StepFunction) *StepFunction {
+	return combine(a, b, func(x, y float64) float64 { return x + y })
+}
+
+// Mul returns the function a × b.
+func Mul(a, b *StepFunction) *StepFunction {
+	return combine(a, b, func(x, y float64) float64 { return x * y })
+}
+
+// Min returns the function whose value at each time is the smaller of the
+// values of a and b.
+func Min(a, b *StepFunction) *StepFunction {
+	return combine(a, b, func(x, y float64) float64 {
+		if y < x {
+			return y
+		}
+		return x
+	})
+}
+
+// Max returns the function whose value at each time is the larger of the
+// values of a and b.
+func Max(a, b *StepFunction) *StepFunction {
+	return combine(a, b, func(x, y float64) float64 {
+		if y > x {
+			return y
+		}
+		return x
+	})
+}
+
+// Map returns the function whose value at each time is g applied to the value
+// of f at that time.
+func (f *StepFunction) Map(g func(float64) float64) *StepFunction {
+	result := newBuilder(g(f.initial))
+	for _, s := range f.steps {
+		result.push(s.at, g(s.value))
+	}
+	return result.f
+}
+
+// PieceReceiver is a function used for iterating over the pieces of a step
+// function. It takes the start (inclusive) and end (exclusive) of a piece and
+// the value of the function within it, and returns true if the iteration
+// should continue.
+type PieceReceiver func(start, end time.Time, value float64) bool
+
+// PiecesBetween calls f with each piece of the function in which its value is
+// constant, truncated to [start, end). If f returns false, iteration ceases.
+func (f *StepFunction) PiecesBetween(start, end time.Time, g PieceReceiver) {
+	if !start.Before(end) {
+		return
+	}
+	i := sort.Search(len(f.steps), func(i int) bool { return f.steps[i].at.After(start) })
+	from, v := start, f.At(start)
+	for ; i < len(f.steps) && f.steps[i].at.Before(end); i++ {
+		if !g(from, f.steps[i].at, v) {
+			return
+		}
+		from, v = f.steps[i].at, f.steps[i].value
+	}
+	g(from, end, v)
+}
+
+// Integrate returns the integral of the function over [start, end), in units
+// of the value multiplied by unit. For example, if the function is a number of
+// engineers, Integrate(start, end, time.Hour) returns engineer-hours.
+func (f *StepFunction) Integrate(start, end time.Time, unit time.Duration) float64 {
+	total := 0.0
+	f.PiecesBetween(start, end, func(s, e time.Time, v float64) bool {
+		if v != 0 {
+			total += v * float64(e.Sub(s)) / float64(unit)
+		}
+		return true
+	})
+	return total
+}
+
+// Where returns the set of times at which pred reports true for the value of
+// the function. Where pred holds before the first step or after the last, the
+// set extends to timespanset.MinTime or timespanset.MaxTime.
+func (f *StepFunction) Where(pred func(v float64) bool) *timespanset.Set {
+	result := timespanset.Empty()
+	f.PiecesBetween(timespanset.MinTime, timespanset.MaxTime, func(s, e time.Time, v float64) bool {
+		if pred(v) {
+			result.Insert(s, e)
+		}
+		return true
+	})
+	return result
+}
@@ -0,0 +1,130 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package series
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-intervals/timespanset"
+)
+
+func at(hour int) time.Time {
+	return time.Date(2015, time.June, 1, hour, 0, 0, 0, time.UTC)
+}
+
+type piece struct {
+	start, end int
+	value      float64
+}
+
+// pieces returns the pieces of f within the day of June 1, with times as
+// hours.
+func pieces(f *StepFunction) []piece {
+	var result []piece
+	f.PiecesBetween(at(0), at(24), func(start, end time.Time, v float64) bool {
+		result = append(result, piece{start.Hour(), int(end.Sub(at(0)).Hours()), v})
+		return true
+	})
+	return result
+}
+
+// onCall has one engineer from 00:00 to 12:00 and another from 08:00 to
+// 20:00, and a trainee with weight 0.5 from 08:00 to 10:00.
+func onCall() *StepFunction {
+	return FromWeighted(
+		WeightedSpan{at(0), at(12), 1},
+		WeightedSpan{at(8), at(20), 1},
+		WeightedSpan{at(8), at(10), 0.5},
+		WeightedSpan{at(22), at(22), 1},
+	)
+}
+
+func TestFromWeighted(t *testing.T) {
+	f := onCall()
+	want := []piece{{0, 8, 1}, {8, 10, 2.5}, {10, 12, 2}, {12, 20, 1}, {20, 24, 0}}
+	if got := pieces(f); !reflect.DeepEqual(got, want) {
+		t.Errorf("FromWeighted() = %v, want %v", got, want)
+	}
+	if got := f.At(at(9)); got != 2.5 {
+		t.Errorf("At(09:00) = %v, want 2.5", got)
+	}
+	if got := f.At(at(20)); got != 0 {
+		t.Errorf("At(20:00) = %v, want 0", got)
+	}
+	if got, want := f.String(), "{-inf: 0, 2015-06-01T00:00:00Z: 1, 2015-06-01T08:00:00Z: 2.5, 2015-06-01T10:00:00Z: 2, 2015-06-01T12:00:00Z: 1, 2015-06-01T20:00:00Z: 0}"; got != want {
+		t.Errorf("String() = %s, want %s", got, want)
+	}
+
+	// Weights that do not sum exactly to zero in floating point still return
+	// to zero when no spans are active.
+	g := FromWeighted(WeightedSpan{at(0), at(2), 0.1}, WeightedSpan{at(1), at(3), 0.2})
+	if got := g.At(at(4)); got != 0 {
+		t.Errorf("At() after all spans = %v, want 0", got)
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	f := onCall()
+	rate := FromWeighted(WeightedSpan{at(6), at(18), 100})
+	base := Constant(50)
+	for _, tt := range []struct {
+		name string
+		got  *StepFunction
+		want []piece
+	}{
+		{"add", Add(f, Constant(1)), []piece{{0, 8, 2}, {8, 10, 3.5}, {10, 12, 3}, {12, 20, 2}, {20, 24, 1}}},
+		{"mul", Mul(f, Add(rate, base)), []piece{{0, 6, 50}, {6, 8, 150}, {8, 10, 375}, {10, 12, 300}, {12, 18, 150}, {18, 20, 50}, {20, 24, 0}}},
+		{"min", Min(f, Constant(2)), []piece{{0, 8, 1}, {8, 12, 2}, {12, 20, 1}, {20, 24, 0}}},
+		{"max", Max(f, Constant(1)), []piece{{0, 8, 1}, {8, 10, 2.5}, {10, 12, 2}, {12, 24, 1}}},
+		{"map", f.Map(math.Floor), []piece{{0, 8, 1}, {8, 12, 2}, {12, 20, 1}, {20, 24, 0}}},
+		{"zero value", Add(&StepFunction{}, &StepFunction{}), []piece{{0, 24, 0}}},
+	} {
+		if got := pieces(tt.got); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestIntegrateAndWhere(t *testing.T) {
+	f := onCall()
+	if got, want := f.Integrate(at(0), at(24), time.Hour), 8+5+4+8.0; got != want {
+		t.Errorf("Integrate() = %v engineer-hours, want %v", got, want)
+	}
+	if got, want := f.Integrate(at(9), at(11), time.Minute), 60*2.5+60*2.0; got != want {
+		t.Errorf("Integrate() = %v engineer-minutes, want %v", got, want)
+	}
+
+	short := f.Where(func(v float64) bool { return v < 2 })
+	var got []timespanset.Span
+	first, last := short.Extent()
+	short.IntervalsBetween(first, last, func(start, end time.Time) bool {
+		got = append(got, timespanset.Span{Start: start, End: end})
+		return true
+	})
+	want := []timespanset.Span{{Start: timespanset.MinTime, End: at(8)}, {Start: at(12), End: timespanset.MaxTime}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Where(< 2) = %v, want %v", got, want)
+	}
+
+	s := timespanset.Empty()
+	s.Insert(at(1), at(3))
+	s.Insert(at(5), at(6))
+	if got, want := pieces(FromSet(s, 4)), []piece{{0, 1, 0}, {1, 3, 4}, {3, 5, 0}, {5, 6, 4}, {6, 24, 0}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FromSet() = %v, want %v", got, want)
+	}
+}
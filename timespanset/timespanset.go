@@ -47,27 +47,40 @@ func (s *Set) Copy() *Set {
 	return &Set{s.iset.Copy()}
 }
 
-// Insert adds a single time span into the set.
-func (s *Set) Insert(start, end time.Time) {
+// Insert adds a single time span into the set. It returns a patch describing
+// the portion of the span that was not already in the set.
+func (s *Set) Insert(start, end time.Time) *Patch {
 	if end.Before(start) {
 		panic(fmt.Errorf("start %s before end %s", start, end))
 	}
-	s.iset.Add(intervalset.NewSet([]intervalset.Interval{&timespan{start, end}}))
+	return fromIntervalPatch(s.iset.Add(intervalset.NewSet([]intervalset.Interval{&timespan{start, end}})))
 }
 
-// Add performs an in-place union of two sets.
-func (s *Set) Add(b *Set) {
-	s.iset.Add(b.iset)
+// Add performs an in-place union of two sets. It returns a patch describing
+// the spans that were actually added.
+func (s *Set) Add(b *Set) *Patch {
+	return fromIntervalPatch(s.iset.Add(b.iset))
 }
 
-// Sub performs an in-place subtraction of set b from set a.
-func (s *Set) Sub(b *Set) {
-	s.iset.Sub(b.iset)
+// Sub performs an in-place subtraction of set b from set a. It returns a patch
+// describing the spans that were actually removed.
+func (s *Set) Sub(b *Set) *Patch {
+	return fromIntervalPatch(s.iset.Sub(b.iset))
 }
 
-// Intersect performs an in-place intersection of sets a and b.
-func (s *Set) Intersect(b *Set) {
-	s.iset.Intersect(b.iset)
+// Intersect performs an in-place intersection of sets a and b. It returns a
+// patch describing the spans that were actually removed.
+func (s *Set) Intersect(b *Set) *Patch {
+	return fromIntervalPatch(s.iset.Intersect(b.iset))
+}
+
+// Observe registers f to be called synchronously after each mutation that
+// changes the set, with a patch describing the change. f must not mutate the
+// set. The returned function unregisters f.
+func (s *Set) Observe(f func(*Patch)) (cancel func()) {
+	return s.iset.Observe(func(p *intervalset.Patch) {
+		f(fromIntervalPatch(p))
+	})
 }
 
 // Extent returns the start and end time that defines the entire timespan
@@ -0,0 +1,164 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timespanset
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/go-intervals/internal/civil"
+)
+
+type bucketUnit int
+
+const (
+	unitDuration bucketUnit = iota
+	unitDay
+	unitWeek
+	unitMonth
+)
+
+// Bucketing describes how time is divided into consecutive buckets for
+// aggregation by Set.Buckets.
+type Bucketing struct {
+	unit    bucketUnit
+	d       time.Duration
+	alignTo time.Time
+}
+
+var (
+	// Daily divides time into calendar days. A day is 23 or 25 hours long
+	// when it contains a daylight saving transition.
+	Daily = Bucketing{unit: unitDay}
+
+	// Weekly divides time into ISO 8601 weeks, which start on Monday.
+	Weekly = Bucketing{unit: unitWeek}
+
+	// Monthly divides time into calendar months.
+	Monthly = Bucketing{unit: unitMonth}
+)
+
+// Every divides time into buckets of fixed duration d, with a bucket
+// boundary at alignTo. It panics if d is not positive.
+func Every(d time.Duration, alignTo time.Time) Bucketing {
+	if d <= 0 {
+		panic(fmt.Errorf("bucket duration %s must be positive", d))
+	}
+	return Bucketing{unitDuration, d, alignTo}
+}
+
+// floor returns the start of the bucket containing t.
+func (b Bucketing) floor(t time.Time, loc *time.Location) time.Time {
+	if b.unit == unitDuration {
+		return b.alignTo.Add(floorDiv(t.Sub(b.alignTo), b.d) * b.d)
+	}
+	t = t.In(loc)
+	switch b.unit {
+	case unitDay:
+		return civil.StartOfDay(t.Year(), t.Month(), t.Day(), loc)
+	case unitWeek:
+		daysSinceMonday := (int(t.Weekday()) + 6) % 7
+		return civil.StartOfDay(t.Year(), t.Month(), t.Day()-daysSinceMonday, loc)
+	default:
+		return civil.StartOfDay(t.Year(), t.Month(), 1, loc)
+	}
+}
+
+// next returns the start of the bucket after the one starting at t.
+func (b Bucketing) next(t time.Time, loc *time.Location) time.Time {
+	if b.unit == unitDuration {
+		return t.Add(b.d)
+	}
+	t = t.In(loc)
+	switch b.unit {
+	case unitDay:
+		return civil.StartOfDay(t.Year(), t.Month(), t.Day()+1, loc)
+	case unitWeek:
+		return civil.StartOfDay(t.Year(), t.Month(), t.Day()+7, loc)
+	default:
+		return civil.StartOfDay(t.Year(), t.Month()+1, 1, loc)
+	}
+}
+
+// Bucket holds the aggregate of a set within a bucket of time.
+type Bucket struct {
+	// Start and End are the bounds of the bucket.
+	Start, End time.Time
+
+	// Covered is the total length of the spans of the set within the bucket.
+	Covered time.Duration
+
+	// Count is the number of spans of the set that overlap the bucket. A span
+	// that crosses a bucket boundary is counted in each bucket it overlaps.
+	Count int
+}
+
+// BucketReceiver is a function used for iterating over buckets. It returns
+// true if the iteration should continue.
+type BucketReceiver func(Bucket) bool
+
+// Buckets divides [start, end) into buckets according to b, with calendar
+// boundaries computed in loc, and calls f with the aggregate of the set within
+// each bucket in increasing order. The first and last buckets are truncated to
+// [start, end). Buckets that do not overlap the set are included. If f
+// returns false, iteration ceases.
+//
+// The set is walked once, rather than intersected with each bucket.
+func (s *Set) Buckets(start, end time.Time, b Bucketing, loc *time.Location, f BucketReceiver) {
+	if !start.Before(end) {
+		return
+	}
+	cur := Bucket{Start: start, End: b.next(b.floor(start, loc), loc)}
+	done := false
+	// advance sends the current bucket to f and moves to the next one. It
+	// returns false once there are no more buckets or f asks to stop.
+	advance := func() bool {
+		if cur.End.After(end) {
+			cur.End = end
+		}
+		if !f(cur) || !cur.End.Before(end) {
+			done = true
+			return false
+		}
+		cur = Bucket{Start: cur.End, End: b.next(cur.End, loc)}
+		return true
+	}
+	s.IntervalsBetween(start, end, func(spanStart, spanEnd time.Time) bool {
+		for !cur.End.After(spanStart) {
+			if !advance() {
+				return false
+			}
+		}
+		for {
+			from, to := spanStart, spanEnd
+			if from.Before(cur.Start) {
+				from = cur.Start
+			}
+			if to.After(cur.End) {
+				to = cur.End
+			}
+			cur.Covered += to.Sub(from)
+			cur.Count++
+			if !spanEnd.After(cur.End) {
+				return true
+			}
+			if !advance() {
+				return false
+			}
+		}
+	})
+	for !done && advance() {
+	}
+}
@@ -118,3 +118,54 @@ func (ts *timespan) encompass(b *timespan) intervalset.Interval {
 func (ts *timespan) Encompass(other intervalset.Interval) intervalset.Interval {
 	return ts.encompass(trOrPanic(other))
 }
+
+// spanOrZero returns the span between a and b, swapping them if b is before a.
+// The zero timespan is returned if a and b are equal.
+func spanOrZero(a, b time.Time) *timespan {
+	if a.Equal(b) {
+		return &timespan{}
+	}
+	if b.Before(a) {
+		a, b = b, a
+	}
+	return &timespan{a, b}
+}
+
+func timeOrPanic(x interface{}) time.Time {
+	t, ok := x.(time.Time)
+	if !ok {
+		panic(fmt.Errorf("endpoint must be a time.Time: %v", x))
+	}
+	return t
+}
+
+func (ts *timespan) MapEndpoints(f func(interface{}) interface{}) intervalset.Interval {
+	return spanOrZero(timeOrPanic(f(ts.start)), timeOrPanic(f(ts.end)))
+}
+
+func (ts *timespan) Translate(offset interface{}) intervalset.Interval {
+	d, ok := offset.(time.Duration)
+	if !ok {
+		panic(fmt.Errorf("offset must be a time.Duration: %v", offset))
+	}
+	return &timespan{ts.start.Add(d), ts.end.Add(d)}
+}
+
+func (ts *timespan) Scale(origin interface{}, factor float64) intervalset.Interval {
+	o := timeOrPanic(origin)
+	scale := func(t time.Time) time.Time {
+		return o.Add(time.Duration(float64(t.Sub(o)) * factor))
+	}
+	return spanOrZero(scale(ts.start), scale(ts.end))
+}
+
+func (ts *timespan) Split(point interface{}) (intervalset.Interval, intervalset.Interval) {
+	t := timeOrPanic(point)
+	if !t.After(ts.start) {
+		return &timespan{}, ts
+	}
+	if !t.Before(ts.end) {
+		return ts, &timespan{}
+	}
+	return &timespan{ts.start, t}, &timespan{t, ts.end}
+}
@@ -0,0 +1,263 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timespanset
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-intervals/intervalset"
+)
+
+var (
+	// MinTime is the start of spans that are unbounded in the past, such as
+	// those parsed from the ISO 8601 interval "../2015-06-08".
+	MinTime = time.Date(0, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	// MaxTime is the end of spans that are unbounded in the future, such as
+	// those parsed from the ISO 8601 interval "2015-06-01/..".
+	MaxTime = time.Date(10000, time.January, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// maxRepetitions limits the number of repetitions of a repeating interval,
+// which are expanded one at a time.
+const maxRepetitions = 1000000
+
+// ParseISO8601 parses a comma or space separated list of ISO 8601 time
+// intervals and returns their union. Each interval has one of the forms
+//
+//	start/end        2015-06-01T00:00Z/2015-06-08T00:00Z
+//	start/duration   2015-06-01/P1W
+//	duration/end     P1D/2015-06-08
+//
+// optionally preceded by a repetition "Rn/" or, for an unbounded number of
+// repetitions, "R/". Either end of a start/end interval may be ".." for a span
+// that is unbounded in that direction, which extends to MinTime or MaxTime.
+//
+// The number of repetitions must be positive. Each repetition starts where the
+// previous one ends, so a repeating interval covers a single span. A start/end
+// interval repeats by the calendar days between start and end, in the location
+// of start, plus any exact remainder. So "R3/2015-03-07/2015-03-08" ends at
+// midnight on March 10, even if a daylight saving transition makes one of
+// those days shorter.
+//
+// Times use the extended format, such as 2015-06-01T13:30:00.5+01:00, and may
+// be reduced to minutes or to a date, which means the start of that day. Times
+// without a UTC offset are in loc. The years, months, weeks and days of a
+// duration are nominal: P1D is one calendar day in the location of the time
+// it is added to. Fractions are only allowed in seconds and must use a period,
+// since a comma separates intervals.
+func ParseISO8601(text string, loc *time.Location) (*Set, error) {
+	result := Empty()
+	for _, field := range strings.FieldsFunc(text, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\n' || r == '\r'
+	}) {
+		start, end, err := parseISOInterval(field, loc)
+		if err != nil {
+			return nil, fmt.Errorf("timespanset: invalid ISO 8601 interval %q: %v", field, err)
+		}
+		result.Insert(start, end)
+	}
+	return result, nil
+}
+
+func parseISOInterval(text string, loc *time.Location) (start, end time.Time, err error) {
+	parts := strings.Split(text, "/")
+	repetitions := 1
+	if strings.HasPrefix(parts[0], "R") {
+		if parts[0] == "R" {
+			repetitions = -1
+		} else if repetitions, err = strconv.Atoi(parts[0][1:]); err != nil || repetitions < 1 || repetitions > maxRepetitions {
+			return start, end, fmt.Errorf("invalid number of repetitions %q", parts[0])
+		}
+		parts = parts[1:]
+	}
+	if len(parts) != 2 {
+		return start, end, fmt.Errorf("want two parts separated by /")
+	}
+	first, second := parts[0], parts[1]
+	switch {
+	case strings.HasPrefix(first, "P") && strings.HasPrefix(second, "P"):
+		return start, end, fmt.Errorf("both parts are durations")
+
+	case strings.HasPrefix(first, "P"):
+		d, err := parseISODuration(first)
+		if err != nil {
+			return start, end, err
+		}
+		if end, err = parseISOTime(second, loc); err != nil {
+			return start, end, err
+		}
+		if repetitions < 0 {
+			return MinTime, end, nil
+		}
+		start = end
+		for i := 0; i < repetitions; i++ {
+			start = d.addTo(start, -1)
+		}
+		if start.After(end) {
+			return start, end, fmt.Errorf("duration is negative")
+		}
+		return start, end, nil
+
+	case strings.HasPrefix(second, "P"):
+		d, err := parseISODuration(second)
+		if err != nil {
+			return start, end, err
+		}
+		if start, err = parseISOTime(first, loc); err != nil {
+			return start, end, err
+		}
+		if repetitions < 0 {
+			return start, MaxTime, nil
+		}
+		end = start
+		for i := 0; i < repetitions; i++ {
+			end = d.addTo(end, 1)
+		}
+		if end.Before(start) {
+			return start, end, fmt.Errorf("duration is negative")
+		}
+		return start, end, nil
+	}
+
+	start, end = MinTime, MaxTime
+	if first != ".." {
+		if start, err = parseISOTime(first, loc); err != nil {
+			return start, end, err
+		}
+	}
+	if second != ".." {
+		if end, err = parseISOTime(second, loc); err != nil {
+			return start, end, err
+		}
+	}
+	if end.Before(start) {
+		return start, end, fmt.Errorf("end is before start")
+	}
+	if repetitions != 1 && (first == ".." || second == "..") {
+		return start, end, fmt.Errorf("open-ended intervals can not repeat")
+	}
+	if repetitions < 0 {
+		return start, MaxTime, nil
+	}
+	d := nominalDiff(start, end)
+	for i := 1; i < repetitions; i++ {
+		end = d.addTo(end, 1)
+	}
+	return start, end, nil
+}
+
+// nominalDiff returns the duration from start to end as a number of calendar
+// days in the location of start, plus an exact remainder. end must not be
+// before start.
+func nominalDiff(start, end time.Time) isoDuration {
+	date := func(t time.Time) time.Time {
+		y, m, d := t.In(start.Location()).Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+	days := int(date(end).Sub(date(start)) / (24 * time.Hour))
+	if start.AddDate(0, 0, days).After(end) {
+		days--
+	}
+	return isoDuration{days: days, exact: end.Sub(start.AddDate(0, 0, days))}
+}
+
+// isoTimeLayouts are the layouts accepted by parseISOTime, in the extended
+// ISO 8601 format.
+var isoTimeLayouts = []string{
+	"2006-01-02T15:04:05.999999999Z07:00",
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+func parseISOTime(text string, loc *time.Location) (time.Time, error) {
+	for _, layout := range isoTimeLayouts {
+		if t, err := time.ParseInLocation(layout, text, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q", text)
+}
+
+// isoDuration is an ISO 8601 duration. Years, months and days are nominal.
+type isoDuration struct {
+	years, months, days int
+	exact               time.Duration
+}
+
+var isoDurationPattern = regexp.MustCompile(`^P(?:(\d+)Y)?(?:(\d+)M)?(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+
+func parseISODuration(text string) (isoDuration, error) {
+	m := isoDurationPattern.FindStringSubmatch(text)
+	if m == nil || text == "P" || strings.HasSuffix(text, "T") {
+		return isoDuration{}, fmt.Errorf("invalid duration %q", text)
+	}
+	n := make([]int, 7)
+	for i, s := range m[1:7] {
+		if s != "" {
+			v, err := strconv.Atoi(s)
+			if err != nil {
+				return isoDuration{}, fmt.Errorf("invalid duration %q", text)
+			}
+			n[i] = v
+		}
+	}
+	d := isoDuration{
+		years:  n[0],
+		months: n[1],
+		days:   7*n[2] + n[3],
+		exact:  time.Duration(n[4])*time.Hour + time.Duration(n[5])*time.Minute,
+	}
+	if s := m[7]; s != "" {
+		secs, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return isoDuration{}, fmt.Errorf("invalid duration %q", text)
+		}
+		d.exact += time.Duration(secs * float64(time.Second))
+	}
+	return d, nil
+}
+
+// addTo returns t plus the duration multiplied by sign, which is 1 or -1.
+func (d isoDuration) addTo(t time.Time, sign int) time.Time {
+	return t.AddDate(sign*d.years, sign*d.months, sign*d.days).Add(time.Duration(sign) * d.exact)
+}
+
+// ISO8601 returns the spans of the set as a comma separated list of ISO 8601
+// start/end intervals, which ParseISO8601 accepts. Spans that start at
+// MinTime or end at MaxTime are written as open-ended intervals. Times are
+// written in their own location.
+func (s *Set) ISO8601() string {
+	var parts []string
+	s.iset.Intervals(func(x intervalset.Interval) bool {
+		tr := trOrPanic(x)
+		parts = append(parts, formatISOTime(tr.start, MinTime)+"/"+formatISOTime(tr.end, MaxTime))
+		return true
+	})
+	return strings.Join(parts, ",")
+}
+
+func formatISOTime(t, open time.Time) string {
+	if t.Equal(open) {
+		return ".."
+	}
+	return t.Format(time.RFC3339Nano)
+}
@@ -0,0 +1,102 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timespanset
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/go-intervals/intervalset"
+)
+
+// Span is a time span that includes Start and excludes End.
+type Span struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// String returns a human readable version of the span.
+func (s Span) String() string {
+	return fmt.Sprintf("[%s, %s)", s.Start, s.End)
+}
+
+// Diff returns the spans that are in newSet but not oldSet, and the spans that
+// are in oldSet but not newSet.
+func Diff(oldSet, newSet *Set) (added, removed *Set) {
+	a, r := intervalset.Diff(oldSet.iset, newSet.iset)
+	return &Set{a}, &Set{r}
+}
+
+// Patch is a set of edits that can be applied to a Set: Removed is subtracted
+// from the set, then Added is added to it. Patches may be serialized, for
+// example with encoding/json.
+type Patch struct {
+	Added   []Span `json:"added,omitempty"`
+	Removed []Span `json:"removed,omitempty"`
+}
+
+// NewPatch returns a patch that transforms oldSet into newSet.
+func NewPatch(oldSet, newSet *Set) *Patch {
+	return fromIntervalPatch(intervalset.NewPatch(oldSet.iset, newSet.iset))
+}
+
+// IsEmpty reports whether applying the patch has no effect on any set.
+func (p *Patch) IsEmpty() bool {
+	return len(p.Added) == 0 && len(p.Removed) == 0
+}
+
+// Apply destructively modifies s by applying the patch to it.
+func (p *Patch) Apply(s *Set) {
+	p.intervalPatch().Apply(s.iset)
+}
+
+// Then returns a patch that is equivalent to applying p followed by next.
+func (p *Patch) Then(next *Patch) *Patch {
+	return fromIntervalPatch(p.intervalPatch().Then(next.intervalPatch()))
+}
+
+func (p *Patch) intervalPatch() *intervalset.Patch {
+	return &intervalset.Patch{
+		Added:   spansToIntervals(p.Added),
+		Removed: spansToIntervals(p.Removed),
+	}
+}
+
+func fromIntervalPatch(p *intervalset.Patch) *Patch {
+	return &Patch{
+		Added:   intervalsToSpans(p.Added),
+		Removed: intervalsToSpans(p.Removed),
+	}
+}
+
+func spansToIntervals(spans []Span) []intervalset.Interval {
+	var result []intervalset.Interval
+	for _, s := range spans {
+		if s.End.Before(s.Start) {
+			panic(fmt.Errorf("end %s before start %s", s.End, s.Start))
+		}
+		result = append(result, &timespan{s.Start, s.End})
+	}
+	return result
+}
+
+func intervalsToSpans(intervals []intervalset.Interval) []Span {
+	var result []Span
+	for _, x := range intervals {
+		tr := trOrPanic(x)
+		result = append(result, Span{tr.start, tr.end})
+	}
+	return result
+}
@@ -0,0 +1,117 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timespanset
+
+import (
+	"fmt"
+	"time"
+)
+
+// Clock supplies the current time to a RollingSet. Tests may supply a fake
+// clock to make a RollingSet deterministic.
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+// SystemClock is a Clock that returns time.Now().
+var SystemClock Clock = systemClock{}
+
+// RollingSet is a set of time spans that only retains the spans within a
+// window of time before the current time, for example to report coverage
+// over the last 24 hours of a long running monitor. Spans that end more than
+// the window before the current time are discarded, so the size of the set
+// stays bounded.
+type RollingSet struct {
+	window time.Duration
+	clock  Clock
+	set    *Set
+}
+
+var _ Source = (*RollingSet)(nil)
+
+// NewRollingSet returns an empty RollingSet that retains the last window of
+// time according to clock. If clock is nil, SystemClock is used. It panics if
+// window is not positive.
+func NewRollingSet(window time.Duration, clock Clock) *RollingSet {
+	if window <= 0 {
+		panic(fmt.Errorf("window %s must be positive", window))
+	}
+	if clock == nil {
+		clock = SystemClock
+	}
+	return &RollingSet{window, clock, Empty()}
+}
+
+// Window returns the length of time retained by the set.
+func (r *RollingSet) Window() time.Duration {
+	return r.window
+}
+
+// trim discards the portion of the set before the window and returns the
+// start of the window.
+func (r *RollingSet) trim() time.Time {
+	start := r.clock.Now().Add(-r.window)
+	r.set.TrimBefore(start)
+	return start
+}
+
+// Insert adds a time span to the set. The portion of the span before the
+// window is discarded.
+func (r *RollingSet) Insert(start, end time.Time) {
+	if end.Before(start) {
+		panic(fmt.Errorf("start %s before end %s", start, end))
+	}
+	if from := r.trim(); start.Before(from) {
+		start = from
+	}
+	if start.Before(end) {
+		r.set.Insert(start, end)
+	}
+}
+
+// Set returns a copy of the spans within the window. The copy may contain
+// spans after the current time if they were inserted.
+func (r *RollingSet) Set() *Set {
+	r.trim()
+	return r.set.Copy()
+}
+
+// Coverage returns the total length of the spans between the start of the
+// window and the current time.
+func (r *RollingSet) Coverage() time.Duration {
+	start := r.trim()
+	var total time.Duration
+	r.set.IntervalsBetween(start, start.Add(r.window), func(s, e time.Time) bool {
+		total += e.Sub(s)
+		return true
+	})
+	return total
+}
+
+// IntervalsBetween calls f with the start (inclusive) and end (exclusive) of
+// each span within the window that overlaps [start, end), truncated to
+// [start, end). If f returns false, iteration ceases.
+func (r *RollingSet) IntervalsBetween(start, end time.Time, f IntervalReceiver) {
+	if from := r.trim(); start.Before(from) {
+		start = from
+	}
+	if start.Before(end) {
+		r.set.IntervalsBetween(start, end, f)
+	}
+}
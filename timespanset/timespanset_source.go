@@ -0,0 +1,41 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timespanset
+
+import "time"
+
+// Source is a possibly infinite collection of time ranges whose members are
+// computed on demand, such as the occurrences of a recurring event. *Set is a
+// Source.
+type Source interface {
+	// IntervalsBetween calls f with the start (inclusive) and end (exclusive)
+	// of each time range of the source that overlaps [start, end), truncated
+	// to [start, end). Ranges are passed in increasing order and never overlap
+	// or adjoin. If f returns false, iteration ceases.
+	IntervalsBetween(start, end time.Time, f IntervalReceiver)
+}
+
+var _ Source = (*Set)(nil)
+
+// Between returns a new set containing the time ranges of src within
+// [start, end).
+func Between(src Source, start, end time.Time) *Set {
+	result := Empty()
+	src.IntervalsBetween(start, end, func(s, e time.Time) bool {
+		result.Insert(s, e)
+		return true
+	})
+	return result
+}
@@ -0,0 +1,118 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timespanset
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/go-intervals/intervalset"
+)
+
+// SplitAt cuts the set at each of the given times and returns len(points)+1
+// sets. After sorting points, the first set contains the portion of s before
+// points[0], the set at index i contains the portion of s in
+// [points[i-1], points[i]), and the last set contains the portion at or after
+// the last point. Some of the returned sets may be empty.
+//
+// The set itself is not modified.
+func (s *Set) SplitAt(points ...time.Time) []*Set {
+	sorted := append([]time.Time(nil), points...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Before(sorted[j]) })
+	args := make([]interface{}, len(sorted))
+	for i, t := range sorted {
+		args[i] = t
+	}
+	var result []*Set
+	for _, part := range s.iset.SplitAt(args...) {
+		result = append(result, &Set{part})
+	}
+	return result
+}
+
+// Chunk cuts the set into the cells of a grid of width d that has a cell
+// boundary at alignTo, and returns the non-empty portions of s within each
+// cell, in increasing order. A returned set may contain more than one span if
+// s has a gap within the cell.
+//
+// For example, s.Chunk(15*time.Minute, midnight) returns the portion of s in
+// each 15 minute slot of the day that s overlaps. Chunk panics if d is not
+// positive. The set itself is not modified.
+func (s *Set) Chunk(d time.Duration, alignTo time.Time) []*Set {
+	if d <= 0 {
+		panic(fmt.Errorf("chunk duration %s must be positive", d))
+	}
+	var result []*Set
+	var cell []intervalset.Interval
+	var cellStart time.Time
+	flush := func() {
+		if len(cell) != 0 {
+			result = append(result, &Set{intervalset.NewSet(cell)})
+		}
+		cell = nil
+	}
+	s.iset.Intervals(func(x intervalset.Interval) bool {
+		tr := trOrPanic(x)
+		start := alignTo.Add(floorDiv(tr.start.Sub(alignTo), d) * d)
+		for ; start.Before(tr.end); start = start.Add(d) {
+			if !start.Equal(cellStart) {
+				flush()
+				cellStart = start
+			}
+			cell = append(cell, tr.intersect(&timespan{start, start.Add(d)}))
+		}
+		return true
+	})
+	flush()
+	return result
+}
+
+// floorDiv returns a / b rounded towards negative infinity.
+func floorDiv(a, b time.Duration) time.Duration {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
+
+// TrimBefore removes the portion of the set before t in O(log n) time plus
+// time proportional to the number of spans removed. It returns a patch
+// describing the spans that were removed.
+func (s *Set) TrimBefore(t time.Time) *Patch {
+	return fromIntervalPatch(s.iset.TrimBefore(t))
+}
+
+// TrimAfter removes the portion of the set at or after t in O(log n) time plus
+// time proportional to the number of spans removed. It returns a patch
+// describing the spans that were removed.
+func (s *Set) TrimAfter(t time.Time) *Patch {
+	return fromIntervalPatch(s.iset.TrimAfter(t))
+}
+
+// Partition groups the spans of the set by the value returned by key and
+// returns a set for each distinct key. The values returned by key must be
+// comparable. The set itself is not modified.
+func (s *Set) Partition(key func(start, end time.Time) interface{}) map[interface{}]*Set {
+	result := make(map[interface{}]*Set)
+	for k, part := range s.iset.Partition(func(x intervalset.Interval) interface{} {
+		tr := trOrPanic(x)
+		return key(tr.start, tr.end)
+	}) {
+		result[k] = &Set{part}
+	}
+	return result
+}
@@ -14,6 +14,7 @@
 package timespanset
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"testing"
@@ -268,6 +269,519 @@ func TestContains(t *testing.T) {
 	}
 }
 
+func TestTransform(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		set    *Set
+		bounds *timespan
+		want   []*timespan
+	}{
+		{
+			name: "weeks 1 and 3 translated by a week",
+			set: func() *Set {
+				w := weeks1And3()
+				w.Translate(7 * 24 * time.Hour)
+				return w
+			}(),
+			bounds: &timespan{past, future},
+			want: []*timespan{
+				week2,
+				{week3.end, week3.end.AddDate(0, 0, 7)},
+			},
+		},
+		{
+			name: "weeks 1 and 3 scaled by 0.5 about week1.start",
+			set: func() *Set {
+				w := weeks1And3()
+				w.Scale(week1.start, 0.5)
+				return w
+			}(),
+			bounds: &timespan{past, future},
+			want: []*timespan{
+				{week1.start, week1.start.Add(84 * time.Hour)},
+				{week2.start, week1.start.Add(252 * time.Hour)},
+			},
+		},
+		{
+			name: "weeks 1 and 3 mirrored about week2.start",
+			set: func() *Set {
+				w := weeks1And3()
+				w.Scale(week2.start, -1)
+				return w
+			}(),
+			bounds: &timespan{past, future},
+			want: []*timespan{
+				{week1.start.AddDate(0, 0, -7), week1.start},
+				{week2.start, week2.end},
+			},
+		},
+		{
+			name: "weeks 1 and 3 with week 3 mapped onto week 2 coalesce",
+			set: func() *Set {
+				w := weeks1And3()
+				w.MapEndpoints(func(t time.Time) time.Time {
+					if t.Before(week3.start) {
+						return t
+					}
+					return t.AddDate(0, 0, -7)
+				})
+				return w
+			}(),
+			bounds: &timespan{past, future},
+			want:   []*timespan{{week1.start, week2.end}},
+		},
+	} {
+		got := betweenSlice(tt.set, tt.bounds.start, tt.bounds.end)
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: time ranges between %s = %s, want %s", tt.name, tt.bounds, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if !got[i].start.Equal(tt.want[i].start) || !got[i].end.Equal(tt.want[i].end) {
+				t.Errorf("%s: time ranges between %s = %s, want %s", tt.name, tt.bounds, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestSplitAt(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2015, time.June, d, 0, 0, 0, 0, tz())
+	}
+	set := Empty()
+	set.Insert(day(1).Add(12*time.Hour), day(3).Add(6*time.Hour))
+	set.Insert(day(5), day(6))
+
+	var got [][]*timespan
+	for _, part := range set.SplitAt(day(3), day(2), day(4)) {
+		got = append(got, betweenSlice(part, past, future))
+	}
+	want := [][]*timespan{
+		{{day(1).Add(12 * time.Hour), day(2)}},
+		{{day(2), day(3)}},
+		{{day(3), day(3).Add(6 * time.Hour)}},
+		{{day(5), day(6)}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SplitAt() = %v, want %v", got, want)
+	}
+}
+
+func TestChunk(t *testing.T) {
+	at := func(h, m int) time.Time {
+		return time.Date(2015, time.June, 1, h, m, 0, 0, tz())
+	}
+	set := Empty()
+	set.Insert(at(9, 0), at(9, 40))
+	set.Insert(at(9, 42), at(10, 0))
+	set.Insert(at(11, 10), at(11, 20))
+
+	var got [][]*timespan
+	for _, part := range set.Chunk(15*time.Minute, at(0, 0)) {
+		got = append(got, betweenSlice(part, past, future))
+	}
+	want := [][]*timespan{
+		{{at(9, 0), at(9, 15)}},
+		{{at(9, 15), at(9, 30)}},
+		{{at(9, 30), at(9, 40)}, {at(9, 42), at(9, 45)}},
+		{{at(9, 45), at(10, 0)}},
+		{{at(11, 10), at(11, 15)}},
+		{{at(11, 15), at(11, 20)}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Chunk() = %v, want %v", got, want)
+	}
+}
+
+func TestPartition(t *testing.T) {
+	weekdays, _ := weekdaysWeekends(2017, 2017)
+	parts := weekdays.Partition(func(start, _ time.Time) interface{} {
+		return start.Month()
+	})
+	if got, want := len(parts), 12; got != want {
+		t.Fatalf("len(Partition()) = %d, want %d", got, want)
+	}
+	// Weekdays are grouped by the month in which each Monday-Friday span
+	// starts, so July 31 - August 4 belongs to July.
+	gotStart, gotEnd := parts[time.August].Extent()
+	if got, want := (&timespan{gotStart, gotEnd}), (&timespan{
+		time.Date(2017, time.August, 7, 0, 0, 0, 0, tz()),
+		time.Date(2017, time.September, 2, 0, 0, 0, 0, tz()),
+	}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Partition()[August].Extent() = %s, want %s", got, want)
+	}
+}
+
+func TestPatch(t *testing.T) {
+	oldSet := weeks1And3()
+	newSet := Empty()
+	newSet.Insert(week1.start.AddDate(0, 0, 3), week3.start.AddDate(0, 0, 1))
+
+	added, removed := Diff(oldSet, newSet)
+	if got, want := betweenSlice(added, past, future), []*timespan{week2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff() added = %s, want %s", got, want)
+	}
+	wantRemoved := []*timespan{
+		{week1.start, week1.start.AddDate(0, 0, 3)},
+		{week3.start.AddDate(0, 0, 1), week3.end},
+	}
+	if got := betweenSlice(removed, past, future); !reflect.DeepEqual(got, wantRemoved) {
+		t.Errorf("Diff() removed = %s, want %s", got, wantRemoved)
+	}
+
+	// Round trip the patch through JSON before applying it.
+	encoded, err := json.Marshal(NewPatch(oldSet, newSet))
+	if err != nil {
+		t.Fatalf("json.Marshal() failed: %v", err)
+	}
+	decoded := &Patch{}
+	if err := json.Unmarshal(encoded, decoded); err != nil {
+		t.Fatalf("json.Unmarshal(%s) failed: %v", encoded, err)
+	}
+	patched := oldSet.Copy()
+	decoded.Apply(patched)
+	if !NewPatch(patched, newSet).IsEmpty() {
+		t.Errorf("patched set = %s, want %s", patched, newSet)
+	}
+
+	// Patches compose.
+	newerSet := weeks123()
+	composed := NewPatch(oldSet, newSet).Then(NewPatch(newSet, newerSet))
+	patched = oldSet.Copy()
+	composed.Apply(patched)
+	if got, want := patched.String(), newerSet.String(); got != want {
+		t.Errorf("set patched with composed patch = %s, want %s", got, want)
+	}
+}
+
+func TestObserve(t *testing.T) {
+	set := weeks1And3()
+	var observed []*Patch
+	cancel := set.Observe(func(p *Patch) {
+		observed = append(observed, p)
+	})
+	defer cancel()
+
+	if p := set.Insert(week1.start, week1.end); !p.IsEmpty() {
+		t.Errorf("Insert(week1) = %+v, want empty patch", p)
+	}
+	p := set.Insert(week1.start, week2.end)
+	if got, want := len(p.Added), 1; got != want || !p.Added[0].Start.Equal(week2.start) || !p.Added[0].End.Equal(week2.end) {
+		t.Errorf("Insert(weeks 1-2).Added = %v, want [%s]", p.Added, week2)
+	}
+	if got, want := len(observed), 1; got != want {
+		t.Fatalf("observer called %d times, want %d", got, want)
+	}
+	if observed[0] == nil || len(observed[0].Added) != 1 {
+		t.Errorf("observed %+v, want a patch adding week 2", observed[0])
+	}
+}
+
+func TestTransactionAndUndo(t *testing.T) {
+	set := weeks1And3()
+	set.EnableHistory(10)
+	want := set.String()
+
+	tx := set.Begin()
+	tx.Insert(week2.start, week2.end)
+	tx.Sub(weeks1And3())
+	if got, want := betweenSlice(set, past, future), []*timespan{week2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("set during transaction = %s, want %s", got, want)
+	}
+	tx.Rollback()
+	if got := set.String(); got != want {
+		t.Errorf("set after Rollback() = %s, want %s", got, want)
+	}
+
+	tx = set.Begin()
+	tx.Insert(week2.start, week2.end)
+	tx.Commit()
+	if !set.Undo() {
+		t.Fatalf("Undo() = false, want true")
+	}
+	if got := set.String(); got != want {
+		t.Errorf("set after Undo() = %s, want %s", got, want)
+	}
+	if !set.Redo() {
+		t.Fatalf("Redo() = false, want true")
+	}
+	if got, want := set.String(), weeks123().String(); got != want {
+		t.Errorf("set after Redo() = %s, want %s", got, want)
+	}
+}
+
+func TestBetween(t *testing.T) {
+	var src Source = weeks1And3()
+	got := betweenSlice(Between(src, week1.end.Add(-time.Hour), week3.start.Add(time.Hour)), past, future)
+	want := []*timespan{
+		{week1.end.Add(-time.Hour), week1.end},
+		{week3.start, week3.start.Add(time.Hour)},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Between() = %s, want %s", got, want)
+	}
+}
+
+func TestTrim(t *testing.T) {
+	set := weeks123()
+	p := set.TrimBefore(week2.start.Add(time.Hour))
+	if got, want := betweenSlice(set, past, future), []*timespan{{week2.start.Add(time.Hour), week3.end}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("TrimBefore() left %s, want %s", got, want)
+	}
+	if got, want := p.Removed, []Span{{week1.start, week2.start.Add(time.Hour)}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("TrimBefore() removed %s, want %s", got, want)
+	}
+	set.TrimAfter(week3.start)
+	if got, want := betweenSlice(set, past, future), []*timespan{{week2.start.Add(time.Hour), week2.end}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("TrimAfter() left %s, want %s", got, want)
+	}
+}
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func TestRollingSet(t *testing.T) {
+	start := time.Date(2015, time.June, 1, 0, 0, 0, 0, tz())
+	clock := &fakeClock{start}
+	r := NewRollingSet(24*time.Hour, clock)
+
+	// Insert an hour of activity every other hour for two days.
+	for h := 0; h < 48; h += 2 {
+		clock.now = start.Add(time.Duration(h+1) * time.Hour)
+		r.Insert(clock.now.Add(-time.Hour), clock.now)
+	}
+	if got, want := r.Coverage(), 12*time.Hour; got != want {
+		t.Errorf("Coverage() = %s, want %s", got, want)
+	}
+	// Only the last day is retained.
+	if got, want := len(betweenSlice(r.Set(), past, future)), 12; got != want {
+		t.Errorf("Set() has %d spans, want %d", got, want)
+	}
+
+	clock.now = clock.now.Add(12 * time.Hour)
+	if got, want := r.Coverage(), 6*time.Hour; got != want {
+		t.Errorf("Coverage() after 12 hours = %s, want %s", got, want)
+	}
+	// Spans before the window are never added.
+	r.Insert(past, clock.now.Add(-23*time.Hour))
+	if got, want := betweenSlice(r.Set(), past, future)[0].start, clock.now.Add(-24*time.Hour); !got.Equal(want) {
+		t.Errorf("first span starts at %s, want %s", got, want)
+	}
+	if got := Between(r, past, clock.now.Add(-23*time.Hour)); !got.Contains(clock.now.Add(-24*time.Hour), clock.now.Add(-23*time.Hour)) {
+		t.Errorf("Between() = %s, want it to cover the hour inserted at the start of the window", got)
+	}
+}
+
+func TestBuckets(t *testing.T) {
+	at := func(month time.Month, day, hour int) time.Time {
+		return time.Date(2015, month, day, hour, 0, 0, 0, tz())
+	}
+	set := Empty()
+	set.Insert(at(time.October, 31, 12), at(time.November, 2, 12))
+	set.Insert(at(time.November, 2, 18), at(time.November, 2, 19))
+	set.Insert(at(time.November, 9, 1), at(time.November, 9, 3))
+
+	type bucket struct {
+		start   string
+		length  time.Duration
+		covered time.Duration
+		count   int
+	}
+	collect := func(start, end time.Time, b Bucketing, limit int) []bucket {
+		var result []bucket
+		set.Buckets(start, end, b, tz(), func(x Bucket) bool {
+			result = append(result, bucket{x.Start.Format("Jan 2 15:04 MST"), x.End.Sub(x.Start), x.Covered, x.Count})
+			return len(result) < limit
+		})
+		return result
+	}
+
+	for _, tt := range []struct {
+		name       string
+		start, end time.Time
+		b          Bucketing
+		limit      int
+		want       []bucket
+	}{
+		{
+			// Clocks fall back on November 1, 2015, so that day is 25 hours long.
+			name:  "daily",
+			start: at(time.October, 31, 0),
+			end:   at(time.November, 4, 0),
+			b:     Daily,
+			limit: 100,
+			want: []bucket{
+				{"Oct 31 00:00 PDT", 24 * time.Hour, 12 * time.Hour, 1},
+				{"Nov 1 00:00 PDT", 25 * time.Hour, 25 * time.Hour, 1},
+				{"Nov 2 00:00 PST", 24 * time.Hour, 13 * time.Hour, 2},
+				{"Nov 3 00:00 PST", 24 * time.Hour, 0, 0},
+			},
+		},
+		{
+			name:  "weekly, truncated",
+			start: at(time.October, 30, 0),
+			end:   at(time.November, 12, 0),
+			b:     Weekly,
+			limit: 100,
+			want: []bucket{
+				{"Oct 30 00:00 PDT", 72*time.Hour + time.Hour, 37 * time.Hour, 1},
+				{"Nov 2 00:00 PST", 7 * 24 * time.Hour, 13 * time.Hour, 2},
+				{"Nov 9 00:00 PST", 3 * 24 * time.Hour, 2 * time.Hour, 1},
+			},
+		},
+		{
+			name:  "monthly",
+			start: at(time.October, 1, 0),
+			end:   at(time.December, 1, 0),
+			b:     Monthly,
+			limit: 100,
+			want: []bucket{
+				{"Oct 1 00:00 PDT", 31 * 24 * time.Hour, 12 * time.Hour, 1},
+				{"Nov 1 00:00 PDT", 30*24*time.Hour + time.Hour, 40 * time.Hour, 3},
+			},
+		},
+		{
+			name:  "every 12 hours, stopping early",
+			start: at(time.November, 2, 0),
+			end:   at(time.November, 10, 0),
+			b:     Every(12*time.Hour, at(time.November, 2, 0)),
+			limit: 3,
+			want: []bucket{
+				{"Nov 2 00:00 PST", 12 * time.Hour, 12 * time.Hour, 1},
+				{"Nov 2 12:00 PST", 12 * time.Hour, time.Hour, 1},
+				{"Nov 3 00:00 PST", 12 * time.Hour, 0, 0},
+			},
+		},
+	} {
+		if got := collect(tt.start, tt.end, tt.b, tt.limit); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: Buckets() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBucketsSkippedMidnight(t *testing.T) {
+	// In 2018 Sao Paulo skipped from midnight to 1am on November 4.
+	saoPaulo, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		panic(fmt.Errorf("timezone not available: %v", err))
+	}
+	at := func(day, hour int) time.Time {
+		return time.Date(2018, time.November, day, hour, 0, 0, 0, saoPaulo)
+	}
+	set := Empty()
+	set.Insert(at(3, 22), at(4, 3))
+
+	var got []Bucket
+	set.Buckets(at(3, 0), at(6, 0), Daily, saoPaulo, func(b Bucket) bool {
+		got = append(got, b)
+		return true
+	})
+	want := []Bucket{
+		{at(3, 0), at(4, 1), 2 * time.Hour, 1},
+		{at(4, 1), at(5, 0), 2 * time.Hour, 1},
+		{at(5, 0), at(6, 0), 0, 0},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Buckets() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if !got[i].Start.Equal(want[i].Start) || !got[i].End.Equal(want[i].End) || got[i].Covered != want[i].Covered || got[i].Count != want[i].Count {
+			t.Errorf("Buckets()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+	if got, want := Daily.floor(at(4, 12), saoPaulo), at(4, 1); !got.Equal(want) {
+		t.Errorf("Daily.floor(%s) = %s, want %s", at(4, 12), got, want)
+	}
+}
+
+func TestParseISO8601(t *testing.T) {
+	utc := func(month time.Month, day, hour int) time.Time {
+		return time.Date(2015, month, day, hour, 0, 0, 0, time.UTC)
+	}
+	local := func(month time.Month, day, hour int) time.Time {
+		return time.Date(2015, month, day, hour, 0, 0, 0, tz())
+	}
+	for _, tt := range []struct {
+		text string
+		want []*timespan
+	}{
+		{"2015-06-01T00:00Z/2015-06-08T00:00Z", []*timespan{{utc(time.June, 1, 0), utc(time.June, 8, 0)}}},
+		{"2015-06-01/P1W", []*timespan{{local(time.June, 1, 0), local(time.June, 8, 0)}}},
+		{"P1D/2015-06-08", []*timespan{{local(time.June, 7, 0), local(time.June, 8, 0)}}},
+		// November 1 is 25 hours long, so P1D is nominal.
+		{"2015-11-01T00:00/P1DT1H", []*timespan{{local(time.November, 1, 0), local(time.November, 2, 1)}}},
+		{"2015-06-01T10:00:00.5+02:00/PT0.5S", []*timespan{{utc(time.June, 1, 8).Add(500 * time.Millisecond), utc(time.June, 1, 8).Add(time.Second)}}},
+		{"R5/2015-06-01T00:00Z/PT1H", []*timespan{{utc(time.June, 1, 0), utc(time.June, 1, 5)}}},
+		{"R2/2015-06-01T00:00Z/2015-06-01T03:00Z", []*timespan{{utc(time.June, 1, 0), utc(time.June, 1, 6)}}},
+		// Clocks spring forward on March 8, 2015, so each repetition is a
+		// calendar day rather than 24 hours.
+		{"R3/2015-03-07/2015-03-08", []*timespan{{local(time.March, 7, 0), local(time.March, 10, 0)}}},
+		{"R2/2015-03-07T12:00/2015-03-08T13:30", []*timespan{{local(time.March, 7, 12), local(time.March, 9, 15)}}},
+		{"R3/P1M/2015-06-01T00:00Z", []*timespan{{utc(time.March, 1, 0), utc(time.June, 1, 0)}}},
+		{"R/2015-06-01T00:00Z/PT1H", []*timespan{{utc(time.June, 1, 0), MaxTime}}},
+		{"2015-06-01T00:00Z/..", []*timespan{{utc(time.June, 1, 0), MaxTime}}},
+		{"../2015-06-01T00:00Z", []*timespan{{MinTime, utc(time.June, 1, 0)}}},
+		{
+			"2015-06-01T00:00Z/PT1H, 2015-06-01T02:00Z/PT1H 2015-06-01T01:00Z/PT30M",
+			[]*timespan{{utc(time.June, 1, 0), utc(time.June, 1, 1).Add(30 * time.Minute)}, {utc(time.June, 1, 2), utc(time.June, 1, 3)}},
+		},
+		{"", []*timespan{}},
+	} {
+		s, err := ParseISO8601(tt.text, tz())
+		if err != nil {
+			t.Errorf("ParseISO8601(%q) failed: %v", tt.text, err)
+			continue
+		}
+		got := betweenSlice(s, MinTime, MaxTime)
+		ok := len(got) == len(tt.want)
+		for i := 0; ok && i < len(got); i++ {
+			ok = got[i].start.Equal(tt.want[i].start) && got[i].end.Equal(tt.want[i].end)
+		}
+		if !ok {
+			t.Errorf("ParseISO8601(%q) = %s, want %s", tt.text, got, tt.want)
+		}
+	}
+
+	for _, text := range []string{
+		"2015-06-01",
+		"P1D/P2D",
+		"2015-06-08/2015-06-01",
+		"2015-06-01/P1X",
+		"2015-06-01/PT",
+		"R-1/2015-06-01/P1D",
+		"R0/2015-06-01/P1D",
+		"R0/2015-06-01/2015-06-02",
+		"R2/2015-06-01/..",
+		"2015-13-01/P1D",
+	} {
+		if _, err := ParseISO8601(text, tz()); err == nil {
+			t.Errorf("ParseISO8601(%q) succeeded, want an error", text)
+		}
+	}
+}
+
+func TestISO8601(t *testing.T) {
+	s := Empty()
+	s.Insert(MinTime, time.Date(2015, time.June, 1, 0, 0, 0, 0, time.UTC))
+	s.Insert(time.Date(2015, time.June, 2, 12, 30, 0, 0, tz()), time.Date(2015, time.June, 3, 0, 0, 0, 500, tz()))
+	s.Insert(time.Date(2015, time.June, 8, 0, 0, 0, 0, time.UTC), MaxTime)
+	text := s.ISO8601()
+	if want := "../2015-06-01T00:00:00Z,2015-06-02T12:30:00-07:00/2015-06-03T00:00:00.0000005-07:00,2015-06-08T00:00:00Z/.."; text != want {
+		t.Errorf("ISO8601() = %s, want %s", text, want)
+	}
+	parsed, err := ParseISO8601(text, time.UTC)
+	if err != nil {
+		t.Fatalf("ParseISO8601(%q) failed: %v", text, err)
+	}
+	if got, want := parsed.ISO8601(), text; got != want {
+		t.Errorf("round trip gave %s, want %s", got, want)
+	}
+}
+
 func benchmarkNewSet(numToCreate, numMembers int, overlapping bool, b *testing.B) {
 	for n := 0; n < b.N; n++ {
 		for i := 0; i < numToCreate; i++ {
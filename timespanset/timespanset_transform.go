@@ -0,0 +1,40 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timespanset
+
+import "time"
+
+// Translate shifts every time span in the set by d.
+func (s *Set) Translate(d time.Duration) *Patch {
+	return fromIntervalPatch(s.iset.Translate(d))
+}
+
+// Scale replaces every time t that bounds a span in the set with
+// origin + (t - origin) * factor. A negative factor mirrors the set about
+// origin, and a factor of zero empties it.
+func (s *Set) Scale(origin time.Time, factor float64) *Patch {
+	return fromIntervalPatch(s.iset.Scale(origin, factor))
+}
+
+// MapEndpoints replaces each span [start, end) in the set with
+// [f(start), f(end)). Spans that f maps to an empty span are dropped.
+//
+// If f is monotonically non-decreasing, spans that f makes adjacent are
+// coalesced. Otherwise the resulting spans are re-sorted and merged.
+func (s *Set) MapEndpoints(f func(time.Time) time.Time) *Patch {
+	return fromIntervalPatch(s.iset.MapEndpoints(func(x interface{}) interface{} {
+		return f(timeOrPanic(x))
+	}))
+}
@@ -0,0 +1,101 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timespanset
+
+import (
+	"time"
+
+	"github.com/google/go-intervals/intervalset"
+)
+
+// Tx is a transaction on a Set, created by Set.Begin. Changes made during the
+// transaction are recorded as patches so that they can be rolled back without
+// copying the set.
+type Tx struct {
+	tx *intervalset.Tx
+}
+
+// Begin starts a transaction on the set. Every mutation of the set, whether
+// made through the Tx or directly on the set, is part of the transaction until
+// Commit or Rollback is called.
+//
+// Begin panics if a transaction is already open on the set.
+func (s *Set) Begin() *Tx {
+	return &Tx{s.iset.Begin()}
+}
+
+// Insert adds a single time span into the set. See Set.Insert.
+func (tx *Tx) Insert(start, end time.Time) *Patch {
+	b := Empty()
+	b.Insert(start, end)
+	return tx.Add(b)
+}
+
+// Add performs an in-place union of the set and b. See Set.Add.
+func (tx *Tx) Add(b *Set) *Patch {
+	return fromIntervalPatch(tx.tx.Add(b.iset))
+}
+
+// Sub performs an in-place subtraction of b from the set. See Set.Sub.
+func (tx *Tx) Sub(b *Set) *Patch {
+	return fromIntervalPatch(tx.tx.Sub(b.iset))
+}
+
+// Intersect performs an in-place intersection of the set and b. See
+// Set.Intersect.
+func (tx *Tx) Intersect(b *Set) *Patch {
+	return fromIntervalPatch(tx.tx.Intersect(b.iset))
+}
+
+// Patch returns a patch describing all the changes made during the
+// transaction so far.
+func (tx *Tx) Patch() *Patch {
+	return fromIntervalPatch(tx.tx.Patch())
+}
+
+// Commit ends the transaction, keeping its changes. If history is enabled on
+// the set, the changes are recorded as a single undoable edit.
+func (tx *Tx) Commit() {
+	tx.tx.Commit()
+}
+
+// Rollback ends the transaction, reverting the set to its state when Begin was
+// called. Observers are notified of the reverting changes.
+func (tx *Tx) Rollback() {
+	tx.tx.Rollback()
+}
+
+// EnableHistory starts recording the changes made to the set so they can be
+// reverted with Undo and reapplied with Redo. At most limit edits are kept; the
+// oldest are discarded first. A limit of zero or less disables history.
+//
+// Each mutation is one edit, except that a committed transaction is a single
+// edit.
+func (s *Set) EnableHistory(limit int) {
+	s.iset.EnableHistory(limit)
+}
+
+// Undo reverts the most recent edit recorded in the set's history. It reports
+// false if there is nothing to undo. Undo panics if a transaction is open.
+func (s *Set) Undo() bool {
+	return s.iset.Undo()
+}
+
+// Redo reapplies the most recently undone edit. It reports false if there is
+// nothing to redo. Any mutation of the set other than Undo and Redo clears the
+// edits that may be redone. Redo panics if a transaction is open.
+func (s *Set) Redo() bool {
+	return s.iset.Redo()
+}
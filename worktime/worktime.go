@@ -0,0 +1,166 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package worktime performs arithmetic on working time, such as "add 8 working
+// hours to this timestamp", given working hours, holidays and a time zone.
+//
+// DISCLAIMER: This library is not yet stable, so expect breaking changes.
+package worktime
+
+import (
+	"time"
+
+	"github.com/google/go-intervals/dateset"
+	"github.com/google/go-intervals/recurring"
+	"github.com/google/go-intervals/timespanset"
+)
+
+const (
+	// chunk is the length of time expanded at once when searching for
+	// working time.
+	chunk = 31 * 24 * time.Hour
+
+	// horizon bounds searches for working time, so that a calendar without
+	// any working time does not search forever.
+	horizon = 10 * 366 * 24 * time.Hour
+)
+
+// BusinessCalendar describes the working time of a business: recurring
+// working hours in a location, except on holidays.
+//
+// Working time is computed from the wall-clock working hours on each date, so
+// a day of working hours that spans a daylight saving transition is shorter
+// or longer than usual.
+type BusinessCalendar struct {
+	hours    *recurring.Pattern
+	loc      *time.Location
+	holidays *dateset.Set
+}
+
+var _ timespanset.Source = (*BusinessCalendar)(nil)
+
+// New returns a calendar whose working time is the windows of hours, a daily
+// or weekly pattern, in loc. The calendar has no holidays.
+func New(hours *recurring.Pattern, loc *time.Location) *BusinessCalendar {
+	return &BusinessCalendar{hours.Copy(), loc, dateset.Empty()}
+}
+
+// WithHolidays returns a new calendar in which the given dates are also
+// holidays. Overlays may be combined, for example to add regional holidays to
+// company-wide ones.
+func (c *BusinessCalendar) WithHolidays(holidays *dateset.Set) *BusinessCalendar {
+	h := c.holidays.Copy()
+	h.Add(holidays)
+	return &BusinessCalendar{c.hours, c.loc, h}
+}
+
+// Location returns the location of the working hours of the calendar.
+func (c *BusinessCalendar) Location() *time.Location {
+	return c.loc
+}
+
+// IntervalsBetween calls f with the start (inclusive) and end (exclusive) of
+// each range of working time, truncated to [start, end). If f returns false,
+// iteration ceases.
+func (c *BusinessCalendar) IntervalsBetween(start, end time.Time, f timespanset.IntervalReceiver) {
+	if !start.Before(end) {
+		return
+	}
+	work := c.hours.Expand(start, end, c.loc)
+	days := dateset.Empty()
+	days.InsertRange(dateset.DateOf(start.In(c.loc)), dateset.DateOf(end.In(c.loc)))
+	days.Sub(c.holidays)
+	work.Intersect(days.ToTimespanSet(c.loc))
+	work.IntervalsBetween(start, end, f)
+}
+
+// WorkingDurationBetween returns the amount of working time in [t1, t2). If t2
+// is before t1, the result is negative.
+func (c *BusinessCalendar) WorkingDurationBetween(t1, t2 time.Time) time.Duration {
+	if t2.Before(t1) {
+		return -c.WorkingDurationBetween(t2, t1)
+	}
+	var total time.Duration
+	c.IntervalsBetween(t1, t2, func(start, end time.Time) bool {
+		total += end.Sub(start)
+		return true
+	})
+	return total
+}
+
+// NextWorkingInstant returns t if it is during working time, or else the
+// start of the next working time after t. It returns the zero time if there
+// is no working time within ten years of t.
+func (c *BusinessCalendar) NextWorkingInstant(t time.Time) time.Time {
+	var result time.Time
+	for from := t; result.IsZero() && from.Sub(t) < horizon; from = from.Add(chunk) {
+		c.IntervalsBetween(from, from.Add(chunk), func(start, end time.Time) bool {
+			result = start
+			return false
+		})
+	}
+	return result
+}
+
+// AddWorkingDuration returns the instant at which d of working time has
+// elapsed after t, or before t if d is negative. If the result falls at the
+// end of a range of working time, the end of that range is returned rather
+// than the start of the next one. It returns t if d is zero, and the zero time
+// if there is not enough working time within ten years of t.
+func (c *BusinessCalendar) AddWorkingDuration(t time.Time, d time.Duration) time.Time {
+	if d >= 0 {
+		return c.addForward(t, d)
+	}
+	return c.addBackward(t, -d)
+}
+
+func (c *BusinessCalendar) addForward(t time.Time, d time.Duration) time.Time {
+	if d == 0 {
+		return t
+	}
+	var result time.Time
+	for from := t; result.IsZero() && from.Sub(t) < horizon; from = from.Add(chunk) {
+		c.IntervalsBetween(from, from.Add(chunk), func(start, end time.Time) bool {
+			if n := end.Sub(start); n < d {
+				d -= n
+				return true
+			}
+			result = start.Add(d)
+			return false
+		})
+	}
+	return result
+}
+
+func (c *BusinessCalendar) addBackward(t time.Time, d time.Duration) time.Time {
+	if d == 0 {
+		return t
+	}
+	for to := t; t.Sub(to) < horizon; to = to.Add(-chunk) {
+		var ranges [][2]time.Time
+		c.IntervalsBetween(to.Add(-chunk), to, func(start, end time.Time) bool {
+			ranges = append(ranges, [2]time.Time{start, end})
+			return true
+		})
+		for i := len(ranges) - 1; i >= 0; i-- {
+			start, end := ranges[i][0], ranges[i][1]
+			if n := end.Sub(start); n < d {
+				d -= n
+				continue
+			}
+			return end.Add(-d)
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,134 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worktime
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-intervals/dateset"
+	"github.com/google/go-intervals/recurring"
+)
+
+func tz() *time.Location {
+	x, err := time.LoadLocation("PST8PDT")
+	if err != nil {
+		panic(fmt.Errorf("timezone not available: %v", err))
+	}
+	return x
+}
+
+func at(month time.Month, day, hour int) time.Time {
+	return time.Date(2026, month, day, hour, 0, 0, 0, tz())
+}
+
+func officeHours() *BusinessCalendar {
+	p := recurring.Weekly(time.Hour)
+	for d := time.Monday; d <= time.Friday; d++ {
+		p.Insert(recurring.WeekOffset(d, 9, 0), recurring.WeekOffset(d, 17, 0))
+	}
+	return New(p, tz())
+}
+
+func nightShift() *BusinessCalendar {
+	p := recurring.Daily(time.Hour)
+	p.Insert(22*time.Hour, 6*time.Hour)
+	return New(p, tz())
+}
+
+func holidays(dates ...string) *dateset.Set {
+	s := dateset.Empty()
+	for _, text := range dates {
+		d, err := dateset.ParseDate(text)
+		if err != nil {
+			panic(err)
+		}
+		s.Insert(d)
+	}
+	return s
+}
+
+func TestAddWorkingDuration(t *testing.T) {
+	office := officeHours()
+	// Monday June 8 is a regional holiday.
+	regional := office.WithHolidays(holidays("2026-06-08"))
+
+	// June 5, 2026 is a Friday.
+	for _, tt := range []struct {
+		name string
+		cal  *BusinessCalendar
+		t    time.Time
+		d    time.Duration
+		want time.Time
+	}{
+		{"over the weekend", office, at(time.June, 5, 16), 8 * time.Hour, at(time.June, 8, 16)},
+		{"over a holiday", regional, at(time.June, 5, 16), 8 * time.Hour, at(time.June, 9, 16)},
+		{"ends at closing time", office, at(time.June, 8, 9), 8 * time.Hour, at(time.June, 8, 17)},
+		{"starts outside hours", office, at(time.June, 6, 12), time.Hour, at(time.June, 8, 10)},
+		{"zero", office, at(time.June, 6, 12), 0, at(time.June, 6, 12)},
+		{"backwards", office, at(time.June, 8, 10), -2 * time.Hour, at(time.June, 5, 16)},
+		{"backwards over a holiday", regional, at(time.June, 9, 10), -2 * time.Hour, at(time.June, 5, 16)},
+		{"fall back night", nightShift(), at(time.October, 31, 22), 8 * time.Hour, at(time.November, 1, 5)},
+		{"spring forward night", nightShift(), at(time.March, 7, 22), 7 * time.Hour, at(time.March, 8, 6)},
+	} {
+		if got := tt.cal.AddWorkingDuration(tt.t, tt.d); !got.Equal(tt.want) {
+			t.Errorf("%s: AddWorkingDuration(%s, %s) = %s, want %s", tt.name, tt.t, tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestWorkingDurationBetween(t *testing.T) {
+	office := officeHours()
+	regional := office.WithHolidays(holidays("2026-06-03", "2026-06-04"))
+	for _, tt := range []struct {
+		name   string
+		cal    *BusinessCalendar
+		t1, t2 time.Time
+		want   time.Duration
+	}{
+		{"week", office, at(time.June, 1, 0), at(time.June, 8, 0), 40 * time.Hour},
+		{"week with holidays", regional, at(time.June, 1, 0), at(time.June, 8, 0), 24 * time.Hour},
+		{"reversed", office, at(time.June, 8, 0), at(time.June, 1, 0), -40 * time.Hour},
+		{"partial days", office, at(time.June, 1, 15), at(time.June, 2, 11), 4 * time.Hour},
+		{"fall back night", nightShift(), at(time.October, 31, 12), at(time.November, 1, 12), 9 * time.Hour},
+		{"spring forward night", nightShift(), at(time.March, 7, 12), at(time.March, 8, 12), 7 * time.Hour},
+	} {
+		if got := tt.cal.WorkingDurationBetween(tt.t1, tt.t2); got != tt.want {
+			t.Errorf("%s: WorkingDurationBetween(%s, %s) = %s, want %s", tt.name, tt.t1, tt.t2, got, tt.want)
+		}
+	}
+}
+
+func TestNextWorkingInstant(t *testing.T) {
+	office := officeHours()
+	if got, want := office.NextWorkingInstant(at(time.June, 6, 12)), at(time.June, 8, 9); !got.Equal(want) {
+		t.Errorf("NextWorkingInstant(Saturday) = %s, want %s", got, want)
+	}
+	if got, want := office.NextWorkingInstant(at(time.June, 8, 11)), at(time.June, 8, 11); !got.Equal(want) {
+		t.Errorf("NextWorkingInstant(Monday 11:00) = %s, want %s", got, want)
+	}
+	if got, want := office.NextWorkingInstant(at(time.June, 8, 17)), at(time.June, 9, 9); !got.Equal(want) {
+		t.Errorf("NextWorkingInstant(Monday 17:00) = %s, want %s", got, want)
+	}
+
+	never := New(recurring.Daily(time.Hour), tz())
+	if got := never.NextWorkingInstant(at(time.June, 1, 0)); !got.IsZero() {
+		t.Errorf("NextWorkingInstant() with no working hours = %s, want the zero time", got)
+	}
+	if got := never.AddWorkingDuration(at(time.June, 1, 0), time.Hour); !got.IsZero() {
+		t.Errorf("AddWorkingDuration() with no working hours = %s, want the zero time", got)
+	}
+}